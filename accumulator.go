@@ -0,0 +1,133 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Accumulator keeps a running total of Fixed values in 128 bits, together with their count, so a total
+// such as a daily volume may exceed MAX although every value fits. It holds any sum of fewer than 2^64
+// values without overflow. Like Add, a NaN value makes the total that NaN and an Inf value makes it Inf.
+// The zero value is an empty Accumulator. An Accumulator is not safe for concurrent use
+type Accumulator struct {
+	sum uint128
+	n   uint64
+	// special is the NaN or Inf total, or zero while the total is finite
+	special Fixed
+}
+
+// Add adds f to the total
+func (a *Accumulator) Add(f Fixed) {
+	a.n++
+	if f.IsNaN() || f.IsInf() {
+		a.special = a.mergeSpecial(f)
+		return
+	}
+	a.sum, _ = a.sum.add(uint128{lo: f.fp})
+}
+
+// Sub removes f, previously added, from the total, as when a value leaves a sliding window. An error is
+// returned, leaving the Accumulator unchanged, if f is greater than the total or is NaN or Inf
+func (a *Accumulator) Sub(f Fixed) error {
+	if f.IsNaN() || f.IsInf() {
+		return arithmeticError("Accumulator.Sub", ErrInvalidOperation, f)
+	}
+	if a.sum.cmp(uint128{lo: f.fp}) < 0 {
+		return arithmeticError("Accumulator.Sub", ErrOverflow, f)
+	}
+	hi, lo := a.sum.hi, a.sum.lo-f.fp
+	if lo > a.sum.lo {
+		hi--
+	}
+	a.sum = uint128{hi: hi, lo: lo}
+	if a.n > 0 {
+		a.n--
+	}
+	return nil
+}
+
+// Merge adds the total and count of b to a, as when combining per shard totals
+func (a *Accumulator) Merge(b *Accumulator) {
+	a.sum, _ = a.sum.add(b.sum)
+	a.n += b.n
+	if b.special.fp != 0 {
+		a.special = a.mergeSpecial(b.special)
+	}
+}
+
+// mergeSpecial returns the special total after adding f: the first NaN, else Inf if either is Inf
+func (a *Accumulator) mergeSpecial(f Fixed) Fixed {
+	switch {
+	case a.special.IsNaN():
+		return a.special
+	case f.IsNaN() || f.IsInf():
+		return f
+	}
+	return a.special
+}
+
+// Reset empties the Accumulator
+func (a *Accumulator) Reset() {
+	*a = Accumulator{}
+}
+
+// Count returns the number of values added, less those removed by Sub
+func (a *Accumulator) Count() uint64 {
+	return a.n
+}
+
+// Fixed returns the total. An error is returned if it is greater than MAX
+func (a *Accumulator) Fixed() (Fixed, error) {
+	if a.special.fp != 0 {
+		return a.special, nil
+	}
+	if a.sum.hi != 0 || a.sum.lo > MAX.fp {
+		return NaN, arithmeticError("Accumulator.Fixed", ErrOverflow)
+	}
+	return Fixed{fp: a.sum.lo}, nil
+}
+
+// OriginalBigInt returns the total in original digits, as Fixed.OriginalBigInt, however large it is.
+// A NaN or Inf total returns an error
+func (a *Accumulator) OriginalBigInt() (*big.Int, error) {
+	if a.special.IsNaN() {
+		return nil, ErrNaN
+	}
+	if a.special.IsInf() {
+		return nil, ErrInf
+	}
+	x := new(big.Int).Lsh(new(big.Int).SetUint64(a.sum.hi), 64)
+	return x.Or(x, new(big.Int).SetUint64(a.sum.lo)), nil
+}
+
+// ToDecimal returns the total as a shopspring decimal.Decimal, however large it is. A NaN or Inf total
+// returns an error
+func (a *Accumulator) ToDecimal() (decimal.Decimal, error) {
+	x, err := a.OriginalBigInt()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromBigInt(x, -nPlaces), nil
+}
+
+// String converts the total to a string as Fixed.String does, however large it is
+func (a *Accumulator) String() string {
+	x, err := a.OriginalBigInt()
+	if err != nil {
+		return a.special.String()
+	}
+	s := x.String()
+	if len(s) <= nPlaces {
+		s = zeros[:nPlaces+1-len(s)] + s
+	}
+	point := len(s) - nPlaces
+	frac := strings.TrimRight(s[point:], "0")
+	if frac == "" {
+		return s[:point]
+	}
+	return s[:point] + "." + frac
+}
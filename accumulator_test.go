@@ -0,0 +1,69 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestAccumulator(t *testing.T) {
+	var a Accumulator
+	if a.String() != "0" {
+		t.Error("should be equal", a.String(), "0")
+	}
+	a.Add(NewFromString("1.25"))
+	a.Add(NewFromString("0.00000001"))
+	f, err := a.Fixed()
+	if err != nil || f.String() != "1.25000001" || a.Count() != 2 {
+		t.Error("should be equal", f, a.Count(), "1.25000001", err)
+	}
+
+	// a total beyond MAX
+	a.Reset()
+	for i := 0; i < 3; i++ {
+		a.Add(MAX)
+	}
+	if _, err := a.Fixed(); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	if a.String() != "299999999999.99999997" {
+		t.Error("should be equal", a.String(), "299999999999.99999997")
+	}
+	d, _ := a.ToDecimal()
+	if d.String() != "299999999999.99999997" {
+		t.Error("should be equal", d, "299999999999.99999997")
+	}
+	if err := a.Sub(MAX); err != nil {
+		t.Error(err)
+	}
+	if err := a.Sub(MAX); err != nil {
+		t.Error(err)
+	}
+	f, err = a.Fixed()
+	if err != nil || !f.Equal(MAX) || a.Count() != 1 {
+		t.Error("should be equal", f, MAX, a.Count(), err)
+	}
+	var c Accumulator
+	c.Add(ONE)
+	if err := c.Sub(TWO); !errors.Is(err, ErrOverflow) {
+		t.Error("should not go negative", err)
+	}
+
+	var b Accumulator
+	b.Add(ONE)
+	a.Merge(&b)
+	x, _ := a.OriginalBigInt()
+	if x.String() != "10000000000099999999" || a.Count() != 2 {
+		t.Error("should be equal", x, a.Count())
+	}
+
+	b.Add(Inf)
+	if f, _ := b.Fixed(); !f.IsInf() || b.String() != "Inf" {
+		t.Error("should be Inf", f)
+	}
+	b.Add(NaN)
+	a.Merge(&b)
+	if f, _ := a.Fixed(); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
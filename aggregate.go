@@ -0,0 +1,185 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"iter"
+	"math/big"
+	"slices"
+)
+
+// NaNPolicy selects how the aggregate functions treat NaN values
+type NaNPolicy int
+
+const (
+	// PropagateNaN returns the first NaN found, keeping its NaNReason
+	PropagateNaN NaNPolicy = iota
+	// SkipNaN ignores NaN values, as if they were not there
+	SkipNaN
+)
+
+func (policy NaNPolicy) String() string {
+	switch policy {
+	case PropagateNaN:
+		return "PropagateNaN"
+	case SkipNaN:
+		return "SkipNaN"
+	}
+	return "NaNPolicy(?)"
+}
+
+// Sum returns the sum of values, accumulated in 128 bits so that only a total too large for a Fixed is an
+// error, never an intermediate. Any Inf value makes the sum Inf. An empty sum is zero
+func Sum(values []Fixed, policy NaNPolicy) (Fixed, error) {
+	return SumSeq(slices.Values(values), policy)
+}
+
+// SumSeq is Sum over a sequence
+func SumSeq(values iter.Seq[Fixed], policy NaNPolicy) (Fixed, error) {
+	sum, _, special := sumSeq(values, policy)
+	if special.fp != 0 {
+		return special, nil
+	}
+	if sum.hi != 0 || sum.lo > MAX.fp {
+		return NaN, arithmeticError("Sum", ErrOverflow)
+	}
+	return Fixed{fp: sum.lo}, nil
+}
+
+// Mean returns the arithmetic mean of values rounded according to mode. The sum is accumulated as for
+// Sum, so the mean of values whose total overflows a Fixed is still exact. An error is returned if there
+// are no values, or none but NaN when skipping them
+func Mean(values []Fixed, policy NaNPolicy, mode RoundingMode) (Fixed, error) {
+	return MeanSeq(slices.Values(values), policy, mode)
+}
+
+// MeanSeq is Mean over a sequence
+func MeanSeq(values iter.Seq[Fixed], policy NaNPolicy, mode RoundingMode) (Fixed, error) {
+	sum, n, special := sumSeq(values, policy)
+	if special.fp != 0 {
+		return special, nil
+	}
+	if n == 0 {
+		return NaN, arithmeticError("Mean", ErrEmpty)
+	}
+	q, r := sum.quoRem64(n)
+	if mode.roundUp(q.lo, r, n) {
+		q, _ = q.add(uint128{lo: 1})
+	}
+	// the mean never exceeds the largest value, so it always fits
+	return Fixed{fp: q.lo}, nil
+}
+
+// sumSeq returns the 128 bit sum and count of the finite values. If the result is NaN or Inf instead,
+// it is returned as special, which is otherwise zero
+func sumSeq(values iter.Seq[Fixed], policy NaNPolicy) (sum uint128, n uint64, special Fixed) {
+	for f := range values {
+		switch {
+		case f.IsNaN():
+			if policy == PropagateNaN {
+				return sum, n, f
+			}
+			continue
+		case f.IsInf():
+			special = Inf
+			continue
+		}
+		sum, _ = sum.add(uint128{lo: f.fp})
+		n++
+	}
+	return sum, n, special
+}
+
+// Min returns the smallest of values. An error is returned if there are no values, or none but NaN when
+// skipping them
+func Min(values []Fixed, policy NaNPolicy) (Fixed, error) {
+	return MinSeq(slices.Values(values), policy)
+}
+
+// MinSeq is Min over a sequence
+func MinSeq(values iter.Seq[Fixed], policy NaNPolicy) (Fixed, error) {
+	return extreme("Min", values, policy, -1)
+}
+
+// Max returns the largest of values. An error is returned if there are no values, or none but NaN when
+// skipping them
+func Max(values []Fixed, policy NaNPolicy) (Fixed, error) {
+	return MaxSeq(slices.Values(values), policy)
+}
+
+// MaxSeq is Max over a sequence
+func MaxSeq(values iter.Seq[Fixed], policy NaNPolicy) (Fixed, error) {
+	return extreme("Max", values, policy, 1)
+}
+
+// extreme returns the value v for which v.Cmp(other) == sign against every other value
+func extreme(op string, values iter.Seq[Fixed], policy NaNPolicy, sign int) (Fixed, error) {
+	result, found := NaN, false
+	for f := range values {
+		if f.IsNaN() {
+			if policy == PropagateNaN {
+				return f, nil
+			}
+			continue
+		}
+		if !found || f.Cmp(result) == sign {
+			result, found = f, true
+		}
+	}
+	if !found {
+		return NaN, arithmeticError(op, ErrEmpty)
+	}
+	return result, nil
+}
+
+// WeightedMean returns the mean of values weighted by the corresponding weights, rounded according to
+// mode. Products are accumulated exactly, so no intermediate can overflow. A NaN value or weight is
+// handled according to policy. An Inf value with a non-zero weight makes the mean Inf, while an Inf
+// weight is an error. An error is also returned if the lengths differ or the weights sum to zero
+func WeightedMean(values, weights []Fixed, policy NaNPolicy, mode RoundingMode) (Fixed, error) {
+	if len(values) != len(weights) {
+		return NaN, arithmeticError("WeightedMean", ErrLength)
+	}
+	return WeightedMeanSeq(func(yield func(Fixed, Fixed) bool) {
+		for i, f := range values {
+			if !yield(f, weights[i]) {
+				return
+			}
+		}
+	}, policy, mode)
+}
+
+// WeightedMeanSeq is WeightedMean over a sequence of (value, weight) pairs
+func WeightedMeanSeq(pairs iter.Seq2[Fixed, Fixed], policy NaNPolicy, mode RoundingMode) (Fixed, error) {
+	num, product, weight := new(big.Int), new(big.Int), new(big.Int)
+	var den uint128
+	inf := false
+	for f, w := range pairs {
+		switch {
+		case f.IsNaN() || w.IsNaN():
+			if policy == PropagateNaN {
+				if f.IsNaN() {
+					return f, nil
+				}
+				return w, nil
+			}
+			continue
+		case w.IsInf():
+			return NaN, arithmeticError("WeightedMean", ErrInf, f, w)
+		case f.IsInf():
+			inf = inf || w.fp != 0
+			continue
+		}
+		num.Add(num, product.Mul(product.SetUint64(f.fp), weight.SetUint64(w.fp)))
+		den, _ = den.add(uint128{lo: w.fp})
+	}
+	if inf {
+		return Inf, nil
+	}
+	if den.hi == 0 && den.lo == 0 {
+		return NaN, arithmeticError("WeightedMean", ErrDivisionByZero)
+	}
+	d := new(big.Int).Lsh(new(big.Int).SetUint64(den.hi), 64)
+	// a weighted mean lies between the smallest and largest value, so it always fits
+	return fromBigQuo(num, d.Or(d, new(big.Int).SetUint64(den.lo)), mode)
+}
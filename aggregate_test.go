@@ -0,0 +1,92 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"slices"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []Fixed{NewFromString("1.5"), NewFromString("2.25"), NaN, NewFromString("0.5")}
+
+	f, err := Sum(values, SkipNaN)
+	if err != nil || f.String() != "4.25" {
+		t.Error("should be equal", f, "4.25", err)
+	}
+	f, err = Sum(values, PropagateNaN)
+	if err != nil || !f.IsNaN() {
+		t.Error("should be NaN", f, err)
+	}
+	f, err = Mean(values, SkipNaN, RoundHalfUp)
+	if err != nil || f.String() != "1.41666667" {
+		t.Error("should be equal", f, "1.41666667", err)
+	}
+	f, _ = Min(values, SkipNaN)
+	if f.String() != "0.5" {
+		t.Error("should be equal", f, "0.5")
+	}
+	f, _ = Max(values, SkipNaN)
+	if f.String() != "2.25" {
+		t.Error("should be equal", f, "2.25")
+	}
+	f, _ = MaxSeq(slices.Values(values), PropagateNaN)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+
+	// the total overflows but the mean does not
+	big := []Fixed{MAX, MAX, MAX}
+	if _, err := Sum(big, SkipNaN); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	f, err = Mean(big, SkipNaN, RoundDown)
+	if err != nil || !f.Equal(MAX) {
+		t.Error("should be equal", f, MAX, err)
+	}
+	f, _ = Sum([]Fixed{ONE, Inf}, SkipNaN)
+	if !f.IsInf() {
+		t.Error("should be Inf", f)
+	}
+
+	f, err = Sum(nil, SkipNaN)
+	if err != nil || !f.IsZero() {
+		t.Error("empty sum should be zero", f, err)
+	}
+	if _, err := Mean([]Fixed{NaN}, SkipNaN, RoundDown); !errors.Is(err, ErrEmpty) {
+		t.Error("should be empty", err)
+	}
+	if _, err := Min(nil, SkipNaN); !errors.Is(err, ErrEmpty) {
+		t.Error("should be empty", err)
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	prices := []Fixed{NewFromString("100"), NewFromString("101"), NewFromString("103")}
+	sizes := []Fixed{NewFromString("1"), NewFromString("2"), NewFromString("3")}
+
+	f, err := WeightedMean(prices, sizes, SkipNaN, RoundHalfEven)
+	if err != nil || f.String() != "101.83333333" {
+		t.Error("should be equal", f, "101.83333333", err)
+	}
+
+	// products far beyond 128 bits
+	f, err = WeightedMean([]Fixed{MAX, MAX}, []Fixed{MAX, MAX}, SkipNaN, RoundDown)
+	if err != nil || !f.Equal(MAX) {
+		t.Error("should be equal", f, MAX, err)
+	}
+
+	f, _ = WeightedMean([]Fixed{ONE, NaN}, []Fixed{ONE, ONE}, SkipNaN, RoundDown)
+	if !f.Equal(ONE) {
+		t.Error("should skip NaN", f)
+	}
+	if _, err := WeightedMean(prices, sizes[:1], SkipNaN, RoundDown); !errors.Is(err, ErrLength) {
+		t.Error("should be a length mismatch", err)
+	}
+	if _, err := WeightedMean(prices, []Fixed{ZERO, ZERO, ZERO}, SkipNaN, RoundDown); !errors.Is(err, ErrDivisionByZero) {
+		t.Error("should be division by zero", err)
+	}
+	if _, err := WeightedMean([]Fixed{ONE}, []Fixed{Inf}, SkipNaN, RoundDown); !errors.Is(err, ErrInf) {
+		t.Error("should reject an Inf weight", err)
+	}
+}
@@ -0,0 +1,106 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+	"slices"
+)
+
+// Allocate splits total into parts proportional to weights, each a multiple of step, which sum exactly to
+// total, as when sharing a fill or a fee between accounts. Each part is first given its share rounded down
+// to step; the steps left over are then handed out one each to the parts with the largest remainders, ties
+// going to the earlier part, so the result depends only on the arguments. A zero step allocates in units of
+// the smallest Fixed, 0.00000001. An error is returned if weights is empty or all zero, total is not a
+// multiple of step, or any argument is NaN or Inf
+func Allocate(total Fixed, weights []Fixed, step Fixed) ([]Fixed, error) {
+	if len(weights) == 0 {
+		return nil, arithmeticError("Allocate", ErrEmpty, total)
+	}
+	if err := allocateOperand(total, step); err != nil {
+		return nil, err
+	}
+	if step.fp == 0 {
+		step = Fixed{fp: 1}
+	}
+	if total.fp%step.fp != 0 {
+		return nil, arithmeticError("Allocate", ErrInexact, total, step)
+	}
+
+	sum := new(big.Int)
+	for _, w := range weights {
+		if err := allocateOperand(w); err != nil {
+			return nil, err
+		}
+		sum.Add(sum, new(big.Int).SetUint64(w.fp))
+	}
+	if sum.Sign() == 0 {
+		return nil, arithmeticError("Allocate", ErrDivisionByZero, total)
+	}
+
+	// the share of part i is units * weights[i] / sum steps, exactly
+	units := new(big.Int).SetUint64(total.fp / step.fp)
+	shares := make([]uint64, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	left := total.fp / step.fp
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(units, new(big.Int).SetUint64(w.fp)), sum, new(big.Int))
+		shares[i], remainders[i] = q.Uint64(), r
+		left -= shares[i]
+	}
+
+	// fewer steps are left over than there are parts, so each receives at most one
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return remainders[j].Cmp(remainders[i])
+	})
+	for _, i := range order[:left] {
+		shares[i]++
+	}
+
+	parts := make([]Fixed, len(weights))
+	for i, n := range shares {
+		parts[i] = Fixed{fp: n * step.fp}
+	}
+	return parts, nil
+}
+
+// SplitEven splits f into n parts as equal as the smallest Fixed allows, which sum exactly to f. The
+// 0.00000001 units left over are added one each to the first parts. An error is returned if n is not
+// positive or f is NaN or Inf
+func (f Fixed) SplitEven(n int) ([]Fixed, error) {
+	if n <= 0 {
+		return nil, arithmeticError("SplitEven", ErrEmpty, f)
+	}
+	if f.IsNaN() {
+		return nil, arithmeticError("SplitEven", ErrNaN, f)
+	}
+	if f.IsInf() {
+		return nil, arithmeticError("SplitEven", ErrInf, f)
+	}
+	q, r := f.fp/uint64(n), f.fp%uint64(n)
+	parts := make([]Fixed, n)
+	for i := range parts {
+		parts[i] = Fixed{fp: q}
+		if uint64(i) < r {
+			parts[i].fp++
+		}
+	}
+	return parts, nil
+}
+
+// allocateOperand rejects a NaN or Inf argument of Allocate
+func allocateOperand(operands ...Fixed) error {
+	for _, f := range operands {
+		switch {
+		case f.IsNaN():
+			return arithmeticError("Allocate", ErrNaN, operands...)
+		case f.IsInf():
+			return arithmeticError("Allocate", ErrInf, operands...)
+		}
+	}
+	return nil
+}
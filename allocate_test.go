@@ -0,0 +1,74 @@
+package fixed_test
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func fixedList(s ...string) []Fixed {
+	values := make([]Fixed, len(s))
+	for i := range s {
+		values[i] = NewFromString(s[i])
+	}
+	return values
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total   string
+		weights []Fixed
+		step    string
+		parts   string
+	}{
+		{"100", fixedList("1", "1", "1"), "0", "[33.33333334 33.33333333 33.33333333]"},
+		{"10", fixedList("1", "2", "3"), "0.1", "[1.7 3.3 5]"},
+		{"1", fixedList("0.3333", "0.3333", "0.3334"), "0.01", "[0.33 0.33 0.34]"},
+		{"5", fixedList("0", "2", "0"), "1", "[0 5 0]"},
+		{"0", fixedList("1", "2"), "0", "[0 0]"},
+	}
+	for _, test := range tests {
+		parts, err := Allocate(NewFromString(test.total), test.weights, NewFromString(test.step))
+		if err != nil || fmt.Sprint(parts) != test.parts {
+			t.Error("should be equal", parts, test.parts, err)
+		}
+		sum, _ := Sum(parts, PropagateNaN)
+		if !sum.Equal(NewFromString(test.total)) {
+			t.Error("parts should sum to the total", sum, test.total)
+		}
+	}
+
+	if _, err := Allocate(ONE, nil, ZERO); !errors.Is(err, ErrEmpty) {
+		t.Error("should be empty", err)
+	}
+	if _, err := Allocate(ONE, fixedList("1"), NewFromString("0.3")); !errors.Is(err, ErrInexact) {
+		t.Error("should be inexact", err)
+	}
+	if _, err := Allocate(ONE, fixedList("0", "0"), ZERO); !errors.Is(err, ErrDivisionByZero) {
+		t.Error("should be division by zero", err)
+	}
+	if _, err := Allocate(ONE, []Fixed{ONE, NaN}, ZERO); !errors.Is(err, ErrNaN) {
+		t.Error("should be NaN", err)
+	}
+	if _, err := Allocate(Inf, []Fixed{ONE}, ZERO); !errors.Is(err, ErrInf) {
+		t.Error("should be Inf", err)
+	}
+}
+
+func TestSplitEven(t *testing.T) {
+	parts, err := ONE.SplitEven(3)
+	if err != nil || fmt.Sprint(parts) != "[0.33333334 0.33333333 0.33333333]" {
+		t.Error("should be equal", parts, err)
+	}
+	parts, _ = NewFromString("0.00000002").SplitEven(4)
+	if fmt.Sprint(parts) != "[0.00000001 0.00000001 0 0]" {
+		t.Error("should be equal", parts)
+	}
+	if _, err := ONE.SplitEven(0); !errors.Is(err, ErrEmpty) {
+		t.Error("should be empty", err)
+	}
+	if _, err := NaN.SplitEven(2); !errors.Is(err, ErrNaN) {
+		t.Error("should be NaN", err)
+	}
+}
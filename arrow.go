@@ -0,0 +1,188 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// maximum precision of an Arrow Decimal128 and of a Parquet DECIMAL stored as INT64
+const (
+	decimal128Precision = 38
+	int64Precision      = 18
+)
+
+// ToDecimal128 encodes values as an Arrow Decimal128 data buffer (16 byte little endian two's
+// complement integers) with the given precision and scale, and an Arrow validity bitmap in which
+// NaN values are null. An error is returned if a value is Inf, has more digits than precision or
+// cannot be represented exactly at scale
+func ToDecimal128(values []Fixed, precision, scale int) (data, validity []byte, err error) {
+	if precision < 1 || precision > decimal128Precision {
+		return nil, nil, ErrFormat
+	}
+	data = make([]byte, 16*len(values))
+	validity, err = encodeDecimals(values, precision, scale, 127, func(i int, v uint128) {
+		binary.LittleEndian.PutUint64(data[16*i:], v.lo)
+		binary.LittleEndian.PutUint64(data[16*i+8:], v.hi)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, validity, nil
+}
+
+// FromDecimal128 decodes an Arrow Decimal128 data buffer with the given scale. Null entries in
+// validity become a NaN with reason NaNMissing; a nil validity means every entry is valid
+func FromDecimal128(data, validity []byte, scale int) ([]Fixed, error) {
+	if len(data)%16 != 0 {
+		return nil, ErrFormat
+	}
+	return decodeDecimals(len(data)/16, validity, scale, func(i int) (uint128, error) {
+		v := uint128{hi: binary.LittleEndian.Uint64(data[16*i+8:]), lo: binary.LittleEndian.Uint64(data[16*i:])}
+		if v.hi>>63 != 0 {
+			return v, ErrNegative
+		}
+		return v, nil
+	})
+}
+
+// ToParquetInt64 encodes values as the PLAIN encoding of a Parquet DECIMAL column with an INT64
+// physical type, with NaN values null in the returned validity bitmap. precision may be at most 18
+func ToParquetInt64(values []Fixed, precision, scale int) (data, validity []byte, err error) {
+	if precision < 1 || precision > int64Precision {
+		return nil, nil, ErrFormat
+	}
+	data = make([]byte, 8*len(values))
+	validity, err = encodeDecimals(values, precision, scale, 63, func(i int, v uint128) {
+		binary.LittleEndian.PutUint64(data[8*i:], v.lo)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, validity, nil
+}
+
+// FromParquetInt64 decodes the PLAIN encoding of a Parquet DECIMAL column with an INT64 physical type
+func FromParquetInt64(data, validity []byte, scale int) ([]Fixed, error) {
+	if len(data)%8 != 0 {
+		return nil, ErrFormat
+	}
+	return decodeDecimals(len(data)/8, validity, scale, func(i int) (uint128, error) {
+		v := binary.LittleEndian.Uint64(data[8*i:])
+		if v>>63 != 0 {
+			return uint128{}, ErrNegative
+		}
+		return uint128{lo: v}, nil
+	})
+}
+
+// ToParquetFixedLen encodes values as the PLAIN encoding of a Parquet DECIMAL column with a
+// FIXED_LEN_BYTE_ARRAY physical type of size bytes (big endian two's complement), with NaN values
+// null in the returned validity bitmap. size may be at most 16
+func ToParquetFixedLen(values []Fixed, size, precision, scale int) (data, validity []byte, err error) {
+	if size < 1 || size > 16 || precision < 1 || precision > decimal128Precision {
+		return nil, nil, ErrFormat
+	}
+	data = make([]byte, size*len(values))
+	validity, err = encodeDecimals(values, precision, scale, 8*size-1, func(i int, v uint128) {
+		var buf [16]byte
+		binary.BigEndian.PutUint64(buf[:], v.hi)
+		binary.BigEndian.PutUint64(buf[8:], v.lo)
+		copy(data[size*i:], buf[16-size:])
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, validity, nil
+}
+
+// FromParquetFixedLen decodes the PLAIN encoding of a Parquet DECIMAL column with a
+// FIXED_LEN_BYTE_ARRAY physical type of size bytes
+func FromParquetFixedLen(data, validity []byte, size, scale int) ([]Fixed, error) {
+	if size < 1 || size > 16 || len(data)%size != 0 {
+		return nil, ErrFormat
+	}
+	return decodeDecimals(len(data)/size, validity, scale, func(i int) (uint128, error) {
+		b := data[size*i : size*(i+1)]
+		if b[0]&0x80 != 0 {
+			return uint128{}, ErrNegative
+		}
+		var buf [16]byte
+		copy(buf[16-size:], b)
+		return uint128{hi: binary.BigEndian.Uint64(buf[:]), lo: binary.BigEndian.Uint64(buf[8:])}, nil
+	})
+}
+
+// encodeDecimals rescales each finite value to scale, checks it against precision and the
+// maxBits available below the sign bit, and passes it to put, returning the validity bitmap
+func encodeDecimals(values []Fixed, precision, scale, maxBits int, put func(i int, v uint128)) ([]byte, error) {
+	if scale < -decimal128Precision || scale > decimal128Precision {
+		return nil, ErrFormat
+	}
+	limit, _ := uint128{lo: 1}.mulPow10(precision)
+	validity := make([]byte, (len(values)+7)/8)
+	for i, f := range values {
+		if f.IsNaN() {
+			continue
+		}
+		if f.IsInf() {
+			return nil, fmt.Errorf("value %d: %w", i, ErrInf)
+		}
+		validity[i/8] |= 1 << (i % 8)
+		v, err := rescale(uint128{lo: f.fp}, nPlaces, scale)
+		if err == nil && (v.cmp(limit) >= 0 || v.bitLen() > maxBits) {
+			err = ErrTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		put(i, v)
+	}
+	return validity, nil
+}
+
+// decodeDecimals reads n values with get, rescaling them from scale to a Fixed
+func decodeDecimals(n int, validity []byte, scale int, get func(i int) (uint128, error)) ([]Fixed, error) {
+	if scale < -decimal128Precision || scale > decimal128Precision {
+		return nil, ErrFormat
+	}
+	if validity != nil && len(validity) < (n+7)/8 {
+		return nil, ErrFormat
+	}
+	values := make([]Fixed, n)
+	for i := range values {
+		if validity != nil && validity[i/8]&(1<<(i%8)) == 0 {
+			values[i] = NewNaN(NaNMissing)
+			continue
+		}
+		v, err := get(i)
+		if err == nil {
+			v, err = rescale(v, scale, nPlaces)
+		}
+		if err == nil && (v.hi != 0 || v.lo > MAX.fp) {
+			err = ErrTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		values[i] = Fixed{fp: v.lo}
+	}
+	return values, nil
+}
+
+// rescale converts v from scale from to scale to, failing if digits would be lost
+func rescale(v uint128, from, to int) (uint128, error) {
+	if to >= from {
+		v, ok := v.mulPow10(to - from)
+		if !ok {
+			return v, ErrTooLarge
+		}
+		return v, nil
+	}
+	v, exact := v.quoRemPow10(from - to)
+	if !exact {
+		return v, ErrInexact
+	}
+	return v, nil
+}
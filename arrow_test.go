@@ -0,0 +1,122 @@
+package fixed_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestDecimal128(t *testing.T) {
+	values := []Fixed{NewFromString("1.5"), NaN, NewFromString("12345.12345")}
+
+	data, validity, err := ToDecimal128(values, 20, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(data[:16]) != "00d6117e030000000000000000000000" {
+		t.Error("should be equal", hex.EncodeToString(data[:16]), "00d6117e030000000000000000000000")
+	}
+	if !bytes.Equal(data[16:32], make([]byte, 16)) {
+		t.Error("null slot should be zero", hex.EncodeToString(data[16:32]))
+	}
+	if len(validity) != 1 || validity[0] != 0x05 {
+		t.Error("should be equal", validity, []byte{0x05})
+	}
+
+	values0, err := FromDecimal128(data, validity, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range values {
+		if values[i].Cmp(values0[i]) != 0 {
+			t.Error("don't match", values[i], values0[i])
+		}
+	}
+
+	// the largest value fits precision 38 at scale 27
+	if _, _, err := ToDecimal128([]Fixed{MAX}, 38, 27); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := ToDecimal128([]Fixed{MAX}, 38, 28); err == nil {
+		t.Error("should exceed precision")
+	}
+	if _, _, err := ToDecimal128(values, 5, 2); err == nil {
+		t.Error("should exceed precision")
+	}
+	if _, _, err := ToDecimal128(values, 20, 2); err == nil {
+		t.Error("should lose digits")
+	}
+
+	// negative values are rejected
+	data = make([]byte, 16)
+	data[15] = 0xff
+	if _, err := FromDecimal128(data, nil, 10); err == nil {
+		t.Error("should be negative")
+	}
+}
+
+func TestParquetDecimal(t *testing.T) {
+	values := []Fixed{NewFromString("12345.12345"), NaN, NewFromString("1.5")}
+
+	data, validity, err := ToParquetInt64(values, 18, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(data[:8]) != "d929954900000000" {
+		t.Error("should be equal", hex.EncodeToString(data[:8]), "d929954900000000")
+	}
+	values0, err := FromParquetInt64(data, validity, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range values {
+		if values[i].Cmp(values0[i]) != 0 {
+			t.Error("don't match", values[i], values0[i])
+		}
+	}
+	if _, _, err := ToParquetInt64(values, 19, 5); err == nil {
+		t.Error("precision 19 does not fit INT64")
+	}
+
+	data, validity, err = ToParquetFixedLen(values, 5, 10, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(data) != "00499529d9"+"0000000000"+"00000249f0" {
+		t.Error("should be equal", hex.EncodeToString(data), "00499529d9"+"0000000000"+"00000249f0")
+	}
+	values0, err = FromParquetFixedLen(data, validity, 5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range values {
+		if values[i].Cmp(values0[i]) != 0 {
+			t.Error("don't match", values[i], values0[i])
+		}
+	}
+	if _, _, err := ToParquetFixedLen(values, 3, 10, 5); err == nil {
+		t.Error("should not fit 3 bytes")
+	}
+
+	// values with more than 8 places are only accepted when exact
+	values0, err = FromParquetInt64([]byte{0x64, 0, 0, 0, 0, 0, 0, 0}, nil, 10)
+	if err != nil || values0[0].String() != "0.00000001" {
+		t.Error("should be equal", values0, "0.00000001", err)
+	}
+	_, err = FromParquetInt64([]byte{0x65, 0, 0, 0, 0, 0, 0, 0}, nil, 10)
+	if err == nil {
+		t.Error("should lose digits")
+	}
+	if _, err := FromParquetFixedLen([]byte{0x80, 0, 0}, nil, 3, 2); err == nil {
+		t.Error("should be negative")
+	}
+	if _, err := FromParquetInt64(make([]byte, 16), []byte{}, 2); err == nil {
+		t.Error("short validity bitmap should fail")
+	}
+	_, err = FromParquetInt64([]byte{0, 0, 0, 0, 0, 0, 0, 0x7f}, nil, 0)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Error("should be too large", err)
+	}
+}
@@ -0,0 +1,124 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+)
+
+// AtomicFixed is a Fixed which may be read and updated by several goroutines without a mutex. The zero
+// value is ZERO. An AtomicFixed must not be copied after first use
+type AtomicFixed struct {
+	v atomic.Uint64
+}
+
+// Load atomically returns the value
+func (a *AtomicFixed) Load() Fixed {
+	return Fixed{fp: a.v.Load()}
+}
+
+// Store atomically sets the value to f
+func (a *AtomicFixed) Store(f Fixed) {
+	a.v.Store(f.fp)
+}
+
+// Swap atomically sets the value to f and returns the previous value
+func (a *AtomicFixed) Swap(f Fixed) Fixed {
+	return Fixed{fp: a.v.Swap(f.fp)}
+}
+
+// CompareAndSwap atomically sets the value to f if it is old, reporting whether it did. The comparison
+// is of the encodings, so unlike Equal a NaN matches a NaN with the same NaNReason
+func (a *AtomicFixed) CompareAndSwap(old, f Fixed) bool {
+	return a.v.CompareAndSwap(old.fp, f.fp)
+}
+
+// Add atomically adds f to the value and returns the new value. On overflow an error is returned and the
+// value is left unchanged. NaN and Inf follow the rules of Fixed.Add
+func (a *AtomicFixed) Add(f Fixed) (Fixed, error) {
+	return a.update("Add", '+', f)
+}
+
+// Sub atomically subtracts f from the value and returns the new value. If the result would be negative
+// an error is returned and the value is left unchanged. NaN and Inf follow the rules of Fixed.Sub
+func (a *AtomicFixed) Sub(f Fixed) (Fixed, error) {
+	return a.update("Sub", '-', f)
+}
+
+// update applies op with f in a compare and swap loop
+func (a *AtomicFixed) update(name string, op byte, f Fixed) (Fixed, error) {
+	for {
+		old := a.Load()
+		result, done, err := special(op, old, f)
+		if !done {
+			var ok bool
+			if op == '+' {
+				result, ok = old.add(f)
+			} else {
+				result, ok = old.sub(f)
+			}
+			if !ok {
+				err = ErrOverflow
+			}
+		}
+		if err != nil {
+			return old, arithmeticError("AtomicFixed."+name, err, old, f)
+		}
+		if a.CompareAndSwap(old, result) {
+			return result, nil
+		}
+	}
+}
+
+// StripedCounter is a total which many goroutines may add to at once. Additions are spread over stripes,
+// each an AtomicFixed on its own cache line, so that goroutines rarely contend, at the cost of a slower
+// Load which sums the stripes. Use an AtomicFixed where the total is read as often as it is updated
+type StripedCounter struct {
+	stripes []stripe
+}
+
+type stripe struct {
+	AtomicFixed
+	// keep each stripe on its own cache line
+	_ [56]byte
+}
+
+// NewStripedCounter creates a StripedCounter with n stripes, rounded up to a power of two. If n is not
+// positive, the number of stripes is based on GOMAXPROCS
+func NewStripedCounter(n int) *StripedCounter {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0) * 4
+	}
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return &StripedCounter{stripes: make([]stripe, size)}
+}
+
+// Add adds f to the total. An error is returned if the stripe chosen would overflow, in which case the
+// total is unchanged
+func (c *StripedCounter) Add(f Fixed) error {
+	s := &c.stripes[rand.Uint64()&uint64(len(c.stripes)-1)]
+	_, err := s.Add(f)
+	return err
+}
+
+// Load returns the total of the stripes. As Load does not stop concurrent additions, the total may
+// include some of the additions made while it runs. An error is returned if the total is greater than MAX
+func (c *StripedCounter) Load() (Fixed, error) {
+	var a Accumulator
+	for i := range c.stripes {
+		a.Add(c.stripes[i].Load())
+	}
+	return a.Fixed()
+}
+
+// Reset sets each stripe to zero. Additions made while Reset runs may survive it
+func (c *StripedCounter) Reset() {
+	for i := range c.stripes {
+		c.stripes[i].Store(ZERO)
+	}
+}
@@ -0,0 +1,84 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"sync"
+	"testing"
+)
+
+func TestAtomicFixed(t *testing.T) {
+	var a AtomicFixed
+	if !a.Load().IsZero() {
+		t.Error("zero value should be ZERO", a.Load())
+	}
+	a.Store(ONE)
+	if old := a.Swap(TWO); !old.Equal(ONE) {
+		t.Error("should be equal", old, ONE)
+	}
+	if a.CompareAndSwap(ONE, TEN) || !a.CompareAndSwap(TWO, TEN) || !a.Load().Equal(TEN) {
+		t.Error("should swap only TWO", a.Load())
+	}
+
+	f, err := a.Sub(NewFromString("2.5"))
+	if err != nil || f.String() != "7.5" {
+		t.Error("should be equal", f, "7.5", err)
+	}
+	if _, err := a.Sub(TEN); !errors.Is(err, ErrOverflow) || !a.Load().Equal(f) {
+		t.Error("should not go negative", a.Load(), err)
+	}
+	a.Store(MAX)
+	if _, err := a.Add(MAX); !errors.Is(err, ErrOverflow) || !a.Load().Equal(MAX) {
+		t.Error("should overflow", a.Load(), err)
+	}
+
+	a.Store(ZERO)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_, _ = a.Add(NewFromString("0.01"))
+			}
+		}()
+	}
+	wg.Wait()
+	if a.Load().String() != "80" {
+		t.Error("should be equal", a.Load(), "80")
+	}
+}
+
+func TestStripedCounter(t *testing.T) {
+	c := NewStripedCounter(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_ = c.Add(NewFromString("0.01"))
+			}
+		}()
+	}
+	wg.Wait()
+	f, err := c.Load()
+	if err != nil || f.String() != "80" {
+		t.Error("should be equal", f, "80", err)
+	}
+
+	// the total may exceed what one stripe holds
+	c = NewStripedCounter(3)
+	for i := 0; i < 32; i++ {
+		if err := c.Add(NewFromString("4000000000")); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := c.Load(); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	c.Reset()
+	if f, _ := c.Load(); !f.IsZero() {
+		t.Error("should be zero", f)
+	}
+}
@@ -0,0 +1,199 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// CBOR major types and tags used by the decimal fraction encoding (RFC 8949)
+const (
+	cborUint     = 0
+	cborNegInt   = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborTag      = 6
+	cborSimple   = 7
+	cborTagBig   = 2
+	cborTagFrac  = 4
+	cborNull     = 22
+	cborHalfNaN  = 0x7e00
+	cborHalfInf  = 0x7c00
+	cborMaxBytes = 8
+)
+
+// MarshalCBOR implements the fxamacker/cbor Marshaler interface, encoding f as a tag 4 decimal
+// fraction [-8, fp]. NaN and Inf are encoded as a half precision NaN and positive infinity
+func (f Fixed) MarshalCBOR() ([]byte, error) {
+	if f.IsNaN() {
+		return []byte{cborSimple<<5 | 25, cborHalfNaN >> 8, cborHalfNaN & 0xff}, nil
+	}
+	if f.IsInf() {
+		return []byte{cborSimple<<5 | 25, cborHalfInf >> 8, cborHalfInf & 0xff}, nil
+	}
+	b := appendCBORHead(nil, cborTag, cborTagFrac)
+	b = appendCBORHead(b, cborArray, 2)
+	b = appendCBORHead(b, cborNegInt, nPlaces-1)
+	return appendCBORHead(b, cborUint, f.fp), nil
+}
+
+// UnmarshalCBOR implements the fxamacker/cbor Unmarshaler interface. It accepts a tag 4 decimal
+// fraction with any exponent, an unsigned integer, a text string or a floating point NaN or positive
+// infinity. Like
+// UnmarshalJSON, null leaves f unchanged
+func (f *Fixed) UnmarshalCBOR(data []byte) error {
+	major, arg, rest, err := readCBORHead(data)
+	if err != nil {
+		return err
+	}
+	if major == cborSimple && arg == cborNull && len(rest) == 0 {
+		return nil
+	}
+	var fixed Fixed
+	switch {
+	case major == cborTag && arg == cborTagFrac:
+		fixed, rest, err = readCBORFraction(rest)
+	case major == cborUint:
+		fixed, err = NewFromUintMantissa(arg, 0)
+	case major == cborNegInt:
+		err = ErrNegative
+	case major == cborText:
+		if arg > uint64(len(rest)) {
+			return ErrFormat
+		}
+		fixed, err = NewFromStringErr(string(rest[:arg]))
+		rest = rest[arg:]
+	case major == cborSimple:
+		var ok bool
+		if fixed, ok = cborFloat(data); !ok {
+			err = ErrFormat
+		}
+	default:
+		err = ErrFormat
+	}
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return ErrFormat
+	}
+	*f = fixed
+	return nil
+}
+
+func readCBORFraction(data []byte) (Fixed, []byte, error) {
+	major, arg, data, err := readCBORHead(data)
+	if err != nil || major != cborArray || arg != 2 {
+		return NaN, nil, ErrFormat
+	}
+	major, arg, data, err = readCBORHead(data)
+	if err != nil {
+		return NaN, nil, err
+	}
+	if arg > math.MaxInt32 {
+		return NaN, nil, ErrFormat
+	}
+	exp := int(arg)
+	switch major {
+	case cborUint:
+	case cborNegInt:
+		exp = -1 - exp
+	default:
+		return NaN, nil, ErrFormat
+	}
+	major, arg, data, err = readCBORHead(data)
+	if err != nil {
+		return NaN, nil, err
+	}
+	switch major {
+	case cborUint:
+	case cborNegInt:
+		return NaN, nil, ErrNegative
+	case cborTag:
+		// a positive bignum mantissa small enough to fit an uint64
+		if arg != cborTagBig {
+			return NaN, nil, ErrFormat
+		}
+		major, arg, data, err = readCBORHead(data)
+		if err != nil || major != cborBytes || arg > uint64(len(data)) {
+			return NaN, nil, ErrFormat
+		}
+		if arg > cborMaxBytes {
+			return NaN, nil, ErrTooLarge
+		}
+		var buf [8]byte
+		copy(buf[8-arg:], data[:arg])
+		data = data[arg:]
+		arg = binary.BigEndian.Uint64(buf[:])
+	default:
+		return NaN, nil, ErrFormat
+	}
+	f, err := NewFromUintMantissa(arg, int32(exp))
+	return f, data, err
+}
+
+// cborFloat returns NaN or Inf for a floating point NaN or positive infinity, reporting false for any
+// other simple value
+func cborFloat(data []byte) (Fixed, bool) {
+	var x float64
+	switch data[0] & 0x1f {
+	case 25:
+		switch h := binary.BigEndian.Uint16(data[1:]); {
+		case h == cborHalfInf:
+			return Inf, true
+		case h&cborHalfInf == cborHalfInf && h&0x03ff != 0:
+			return NaN, true
+		}
+		return NaN, false
+	case 26:
+		x = float64(math.Float32frombits(binary.BigEndian.Uint32(data[1:])))
+	case 27:
+		x = math.Float64frombits(binary.BigEndian.Uint64(data[1:]))
+	default:
+		return NaN, false
+	}
+	if math.IsNaN(x) || math.IsInf(x, 1) {
+		return NewFromFloat(x), true
+	}
+	return NaN, false
+}
+
+func appendCBORHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(b, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+}
+
+// readCBORHead decodes the initial byte and argument of a data item. For the floating point
+// encodings of major type 7 the argument is the raw bits
+func readCBORHead(data []byte) (major byte, arg uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, ErrFormat
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info > 27:
+		return 0, 0, nil, ErrFormat
+	}
+	size := 1 << (info - 24)
+	if len(data) < size {
+		return 0, 0, nil, ErrFormat
+	}
+	var buf [8]byte
+	copy(buf[8-size:], data[:size])
+	return major, binary.BigEndian.Uint64(buf[:]), data[size:], nil
+}
@@ -0,0 +1,71 @@
+package fixed_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	f := NewFromString("12345.12345")
+	data, err := f.MarshalCBOR()
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(data, golden(t, "c482271b0000011f6eab77a8")) {
+		t.Error("should be equal", hex.EncodeToString(data), "c482271b0000011f6eab77a8")
+	}
+	var f0 Fixed
+	if err := f0.UnmarshalCBOR(data); err != nil {
+		t.Error(err)
+	}
+	if !f.Equal(f0) {
+		t.Error("don't match", f, f0)
+	}
+
+	data, _ = NaN.MarshalCBOR()
+	if !bytes.Equal(data, golden(t, "f97e00")) {
+		t.Error("should be equal", hex.EncodeToString(data), "f97e00")
+	}
+	data, _ = Inf.MarshalCBOR()
+	if !bytes.Equal(data, golden(t, "f97c00")) {
+		t.Error("should be equal", hex.EncodeToString(data), "f97c00")
+	}
+
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"c48221196ab3", "273.15"},       // RFC 8949 example
+		{"c4820205", "500"},              // positive exponent
+		{"c48227c2420102", "0.00000258"}, // bignum mantissa
+		{"1903e8", "1000"},               // plain integer
+		{"6831322e3334353030", "12.345"}, // text string
+		{"634e614e", "NaN"},              // text NaN
+		{"fb7ff8000000000000", "NaN"},    // double NaN
+		{"f97c00", "Inf"},                // half infinity
+		{"c482271b0000011f6eab77a8", "12345.12345"},
+	}
+	for _, test := range tests {
+		var f Fixed
+		if err := f.UnmarshalCBOR(golden(t, test.data)); err != nil {
+			t.Error(test.data, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", f.String(), test.want)
+		}
+	}
+
+	f0 = ONE
+	if err := f0.UnmarshalCBOR(golden(t, "f6")); err != nil || !f0.Equal(ONE) {
+		t.Error("null should leave the value unchanged", f0, err)
+	}
+
+	for _, s := range []string{"", "20", "c48228190d80", "c48227", "c4830001", "f93c00", "1903", "0101"} {
+		if err := f0.UnmarshalCBOR(golden(t, s)); err == nil {
+			t.Error("should fail", s)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"strings"
+)
+
+// Condition is a set of the exceptional conditions of the General Decimal Arithmetic specification
+// which a Context can signal
+type Condition uint8
+
+const (
+	// Inexact is raised when non-zero digits were discarded to fit the result to the context places
+	Inexact Condition = 1 << iota
+	// Rounded is raised when digits were discarded to fit the result to the context places: non-zero digits,
+	// which also raise Inexact, or the trailing places, even if zeros, of a context with fewer than 8 places
+	Rounded
+	// Overflow is raised when the result is too large, or would be negative
+	Overflow
+	// DivisionByZero is raised when a non-zero number is divided by zero
+	DivisionByZero
+	// InvalidOperation is raised when zero is divided by zero
+	InvalidOperation
+)
+
+var conditionNames = []string{"Inexact", "Rounded", "Overflow", "DivisionByZero", "InvalidOperation"}
+
+func (c Condition) String() string {
+	var names []string
+	for i, name := range conditionNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// Context performs arithmetic with a rounding mode and a number of decimal places, recording exceptional
+// conditions in Flags rather than panicking. Flags are sticky: they accumulate over a block of operations
+// until cleared by the caller. A condition also set in Traps panics instead. Operations on a NaN operand
+// return that NaN and raise nothing. Inf operands follow the rules of the Fixed methods, raising InvalidOperation
+// where those return NaN and Overflow for a finite value minus Inf. A Context is not safe for concurrent use
+type Context struct {
+	// Rounding is applied whenever a result has more than Places decimal places
+	Rounding RoundingMode
+	// Places is the number of decimal places results are rounded to, from 0 to 8
+	Places int
+	// Traps are the conditions which panic when raised
+	Traps Condition
+	// Flags are the conditions raised since they were last cleared
+	Flags Condition
+}
+
+// NewContext creates a Context rounding results to places decimal places according to mode, with no traps
+func NewContext(mode RoundingMode, places int) *Context {
+	return &Context{Rounding: mode, Places: places}
+}
+
+// Add returns f+f0. Overflow returns Inf
+func (c *Context) Add(f, f0 Fixed) Fixed {
+	if result, done := c.special('+', "Add", f, f0); done {
+		return result
+	}
+	sum, ok := f.add(f0)
+	if !ok {
+		return c.raise(Overflow, Inf, "Add", f, f0)
+	}
+	places := c.places(nPlaces)
+	return c.result(sum.fp, 1, pow10[nPlaces-places], places, "Add", f, f0)
+}
+
+// Sub returns f-f0. A negative result is an Overflow and returns NaN
+func (c *Context) Sub(f, f0 Fixed) Fixed {
+	if result, done := c.special('-', "Sub", f, f0); done {
+		return result
+	}
+	difference, ok := f.sub(f0)
+	if !ok {
+		return c.raise(Overflow, NaN, "Sub", f, f0)
+	}
+	places := c.places(nPlaces)
+	return c.result(difference.fp, 1, pow10[nPlaces-places], places, "Sub", f, f0)
+}
+
+// Mul returns f*f0. Overflow returns Inf
+func (c *Context) Mul(f, f0 Fixed) Fixed {
+	if result, done := c.special('*', "Mul", f, f0); done {
+		return result
+	}
+	// f*f0 in units of 10^-places is f.fp*f0.fp / 10^(16-places)
+	places := c.places(nPlaces)
+	return c.result(f.fp, f0.fp, scale*pow10[nPlaces-places], places, "Mul", f, f0)
+}
+
+// Div returns f/f0. Dividing a non-zero number by zero raises DivisionByZero and returns Inf, and zero
+// by zero raises InvalidOperation and returns NaN. Overflow returns Inf
+func (c *Context) Div(f, f0 Fixed) Fixed {
+	if result, done := c.special('/', "Div", f, f0); done {
+		return result
+	}
+	if f0.fp == 0 {
+		if f.fp == 0 {
+			return c.raise(InvalidOperation, NewNaN(NaNDivisionByZero), "Div", f, f0)
+		}
+		return c.raise(DivisionByZero, Inf, "Div", f, f0)
+	}
+	// f/f0 in units of 10^-places is f.fp*10^places / f0.fp
+	places := c.places(nPlaces)
+	return c.result(f.fp, pow10[places], f0.fp, places, "Div", f, f0)
+}
+
+// Round returns f rounded to n decimal places, or to the context places if fewer, using the context rounding mode
+func (c *Context) Round(f Fixed, n int) Fixed {
+	if f.IsNaN() || f.IsInf() {
+		return f
+	}
+	places := c.places(n)
+	return c.result(f.fp, 1, pow10[nPlaces-places], places, "Round", f)
+}
+
+// special returns the result of op for NaN or Inf operands as the Fixed methods do, raising the
+// conditions documented on Context, and reports false if both operands are finite
+func (c *Context) special(op byte, name string, f, f0 Fixed) (Fixed, bool) {
+	result, done, err := special(op, f, f0)
+	if !done || f.IsNaN() || f0.IsNaN() {
+		return result, done
+	}
+	if err != nil {
+		return c.raise(Overflow, result, name, f, f0), true
+	}
+	if result.IsNaN() {
+		return c.raise(InvalidOperation, result, name, f, f0), true
+	}
+	return result, true
+}
+
+// places returns the number of places a result is rounded to: n, or the context places if fewer
+func (c *Context) places(n int) int {
+	places := minInt(minInt(n, c.Places), nPlaces)
+	if places < 0 {
+		return 0
+	}
+	return places
+}
+
+// result rounds the exact result a*b/d, in units of 10^-places, according to the context rounding mode.
+// op and operands describe the operation in case a condition is trapped
+func (c *Context) result(a, b, d uint64, places int, op string, operands ...Fixed) Fixed {
+	q, inexact, ok := mulDivRound(a, b, d, c.Rounding)
+	var raised Condition
+	if inexact {
+		raised |= Inexact | Rounded
+	}
+	if places < nPlaces {
+		// the trailing places of the result are discarded, even if they are zeros
+		raised |= Rounded
+	}
+	step := pow10[nPlaces-places]
+	if !ok || q > MAX.fp/step {
+		return c.raise(raised|Overflow, Inf, op, operands...)
+	}
+	return c.raise(raised, Fixed{fp: q * step}, op, operands...)
+}
+
+// raise records cond in Flags and returns f. If any of cond is trapped it panics with an
+// *ArithmeticError for op applied to operands
+func (c *Context) raise(cond Condition, f Fixed, op string, operands ...Fixed) Fixed {
+	c.Flags |= cond
+	if trapped := cond & c.Traps; trapped != 0 {
+		panic(&ArithmeticError{Op: op, Operands: operands, Err: trapped.err()})
+	}
+	return f
+}
+
+// err returns the error of the most severe condition in c
+func (c Condition) err() error {
+	switch {
+	case c&InvalidOperation != 0:
+		return ErrInvalidOperation
+	case c&DivisionByZero != 0:
+		return ErrDivisionByZero
+	case c&Overflow != 0:
+		return ErrOverflow
+	}
+	return ErrInexact
+}
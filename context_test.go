@@ -0,0 +1,118 @@
+package fixed_test
+
+import (
+	. "github.com/cryptowrold/fixed"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	ctx := NewContext(RoundHalfUp, 8)
+
+	f := ctx.Add(NewFromString("1.5"), NewFromString("2.25"))
+	if f.String() != "3.75" || ctx.Flags != 0 {
+		t.Error("should be equal", f, ctx.Flags, "3.75")
+	}
+
+	// exact results leave the flags clear
+	f = ctx.Mul(NewFromString("1.5"), TWO)
+	if f.String() != "3" || ctx.Flags != 0 {
+		t.Error("should be equal", f, ctx.Flags, "3")
+	}
+	f = ctx.Mul(NewFromString("0.0001"), NewFromString("0.0001"))
+	if f.String() != "0.00000001" || ctx.Flags != 0 {
+		t.Error("should be equal", f, ctx.Flags, "0.00000001")
+	}
+	f = ctx.Div(NewFromString("7.5"), NewFromString("0.25"))
+	if f.String() != "30" || ctx.Flags != 0 {
+		t.Error("should be equal", f, ctx.Flags, "30")
+	}
+
+	f = ctx.Div(TWO, THREE)
+	if f.String() != "0.66666667" || ctx.Flags != Rounded|Inexact {
+		t.Error("should be equal", f, ctx.Flags, "0.66666667", Rounded|Inexact)
+	}
+
+	// flags are sticky
+	f = ctx.Sub(TWO, THREE)
+	if !f.IsNaN() || ctx.Flags != Rounded|Inexact|Overflow {
+		t.Error("should be NaN", f, ctx.Flags)
+	}
+	if ctx.Flags.String() != "Inexact|Rounded|Overflow" {
+		t.Error("should be equal", ctx.Flags.String(), "Inexact|Rounded|Overflow")
+	}
+
+	ctx.Flags = 0
+	f = ctx.Div(ONE, ZERO)
+	if !f.IsInf() || ctx.Flags != DivisionByZero {
+		t.Error("should be Inf", f, ctx.Flags)
+	}
+	ctx.Flags = 0
+	f = ctx.Div(ZERO, ZERO)
+	if !f.IsNaN() || ctx.Flags != InvalidOperation {
+		t.Error("should be NaN", f, ctx.Flags)
+	}
+	ctx.Flags = 0
+	f = ctx.Mul(MAX, TWO)
+	if !f.IsInf() || ctx.Flags&Overflow == 0 {
+		t.Error("should overflow", f, ctx.Flags)
+	}
+	ctx.Flags = 0
+	f = ctx.Add(Inf, ONE)
+	if !f.IsInf() || ctx.Flags != 0 {
+		t.Error("Inf should propagate quietly", f, ctx.Flags)
+	}
+	f = ctx.Sub(Inf, Inf)
+	if !f.IsNaN() || ctx.Flags != InvalidOperation {
+		t.Error("should be invalid", f, ctx.Flags)
+	}
+	ctx.Flags = 0
+	f = ctx.Add(NaN, ONE)
+	if !f.IsNaN() || ctx.Flags != 0 {
+		t.Error("NaN should propagate quietly", f, ctx.Flags)
+	}
+
+	// fewer places and other rounding modes
+	ctx = NewContext(RoundHalfEven, 2)
+	f = ctx.Add(NewFromString("1.004"), NewFromString("0.001"))
+	if f.String() != "1" || ctx.Flags != Rounded|Inexact {
+		t.Error("should be equal", f, ctx.Flags, "1")
+	}
+	f = ctx.Round(NewFromString("2.675"), 4)
+	if f.String() != "2.68" {
+		t.Error("should be equal", f, "2.68")
+	}
+	f = ctx.Round(NewFromString("2.665"), 1)
+	if f.String() != "2.7" {
+		t.Error("should be equal", f, "2.7")
+	}
+	ctx = NewContext(RoundHalfUp, 2)
+	// rounded once from the exact product, not from the product at 8 places
+	f = ctx.Mul(NewFromString("0.00499999"), NewFromString("0.99999999"))
+	if !f.IsZero() || ctx.Flags != Rounded|Inexact {
+		t.Error("should be equal", f, ctx.Flags, "0")
+	}
+	ctx.Flags = 0
+	f = ctx.Div(NewFromString("1.5"), NewFromString("0.5"))
+	if f.String() != "3" || ctx.Flags != Rounded {
+		t.Error("should be equal", f, ctx.Flags, "3", Rounded)
+	}
+	ctx = NewContext(RoundDown, 8)
+	f = ctx.Div(TWO, THREE)
+	if !f.Equal(TWO.Div(THREE)) {
+		t.Error("should match Div", f, TWO.Div(THREE))
+	}
+	if f = ctx.Mul(MAX, MAX); !f.IsInf() || ctx.Flags&Overflow == 0 {
+		t.Error("should overflow", f, ctx.Flags)
+	}
+
+	// trapped conditions panic
+	ctx = NewContext(RoundDown, 8)
+	ctx.Traps = Overflow | DivisionByZero
+	ctx.Div(ONE, THREE)
+	if ctx.Flags != Rounded|Inexact {
+		t.Error("should be equal", ctx.Flags, Rounded|Inexact)
+	}
+	assert.PanicsWithError(t, "fixed: Div(1, 0): division by zero", func() { ctx.Div(ONE, ZERO) })
+	assert.Panics(t, func() { ctx.Sub(ONE, TWO) })
+}
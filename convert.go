@@ -0,0 +1,168 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// NewFromDecimal creates a Fixed from a shopspring decimal.Decimal, rounding according to mode if it
+// has more than 8 decimal places. An error is returned if d is negative or too large
+func NewFromDecimal(d decimal.Decimal, mode RoundingMode) (Fixed, error) {
+	if d.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	if d.Sign() == 0 {
+		return ZERO, nil
+	}
+	// the exponent is bounded only by int32, so results known from it alone are returned before
+	// computing a power of ten which may be enormous
+	exp := int(d.Exponent()) + nPlaces
+	if exp >= len(pow10) {
+		return NaN, ErrTooLarge
+	}
+	if digits := len(d.Coefficient().String()); exp < -digits {
+		// below a tenth of the last place
+		if mode == RoundUp {
+			return Fixed{fp: 1}, nil
+		}
+		return ZERO, nil
+	}
+	if exp >= 0 {
+		return fromBigInt(new(big.Int).Mul(d.Coefficient(), bigPow10(exp)))
+	}
+	return fromBigQuo(d.Coefficient(), bigPow10(-exp), mode)
+}
+
+// ToDecimal converts a Fixed to a shopspring decimal.Decimal. NaN and Inf are not representable and return an error
+func (f Fixed) ToDecimal() (decimal.Decimal, error) {
+	if f.IsNaN() {
+		return decimal.Zero, ErrNaN
+	}
+	if f.IsInf() {
+		return decimal.Zero, ErrInf
+	}
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(f.fp), -nPlaces), nil
+}
+
+// NewFromBigInt creates a Fixed from an integer. An error is returned if x is negative or too large
+func NewFromBigInt(x *big.Int) (Fixed, error) {
+	if x.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	return fromBigInt(new(big.Int).Mul(x, bigPow10(nPlaces)))
+}
+
+// BigInt returns the integer portion of the Fixed. NaN and Inf return an error
+func (f Fixed) BigInt() (*big.Int, error) {
+	if f.IsNaN() {
+		return nil, ErrNaN
+	}
+	if f.IsInf() {
+		return nil, ErrInf
+	}
+	return new(big.Int).SetUint64(f.UInt()), nil
+}
+
+// NewFromOriginalBigInt creates a Fixed from a fixed original integer, as NewFromOriginal. An error
+// is returned if x is negative or too large
+func NewFromOriginalBigInt(x *big.Int) (Fixed, error) {
+	if x.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	return fromBigInt(x)
+}
+
+// OriginalBigInt returns the original digits of the Fixed, as Original. NaN and Inf return an error
+func (f Fixed) OriginalBigInt() (*big.Int, error) {
+	if f.IsNaN() {
+		return nil, ErrNaN
+	}
+	if f.IsInf() {
+		return nil, ErrInf
+	}
+	return new(big.Int).SetUint64(f.fp), nil
+}
+
+// NewFromBigRat creates a Fixed from a rational number, rounding according to mode if it cannot be
+// represented in 8 decimal places. An error is returned if r is negative or too large
+func NewFromBigRat(r *big.Rat, mode RoundingMode) (Fixed, error) {
+	if r.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	return fromBigQuo(new(big.Int).Mul(r.Num(), bigPow10(nPlaces)), r.Denom(), mode)
+}
+
+// BigRat converts a Fixed to an exact rational number. NaN and Inf return an error
+func (f Fixed) BigRat() (*big.Rat, error) {
+	if f.IsNaN() {
+		return nil, ErrNaN
+	}
+	if f.IsInf() {
+		return nil, ErrInf
+	}
+	return new(big.Rat).SetFrac(new(big.Int).SetUint64(f.fp), bigPow10(nPlaces)), nil
+}
+
+// NewFromBigFloat creates a Fixed from the exact value of a big.Float, rounding according to mode if
+// it has more than 8 decimal places. Positive infinity becomes Inf. An error is returned if x is
+// negative or too large
+func NewFromBigFloat(x *big.Float, mode RoundingMode) (Fixed, error) {
+	if x.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	if x.IsInf() {
+		return Inf, nil
+	}
+	if x.Sign() == 0 {
+		return ZERO, nil
+	}
+	// as with NewFromDecimal, results known from the exponent alone are returned before computing an
+	// exact rational which may be enormous. x lies in [2^(exp-1), 2^exp)
+	switch exp := x.MantExp(nil); {
+	case exp > 37:
+		// at least 2^37, above MAX
+		return NaN, ErrTooLarge
+	case exp <= -30:
+		// below 2^-30, a tenth of the last place
+		if mode == RoundUp {
+			return Fixed{fp: 1}, nil
+		}
+		return ZERO, nil
+	}
+	r, _ := x.Rat(nil)
+	return NewFromBigRat(r, mode)
+}
+
+// BigFloat converts a Fixed to a big.Float with prec bits of mantissa, rounded to nearest even. Most
+// decimal fractions are not exact in binary; the Acc method of the result reports the direction of
+// any rounding. Inf becomes positive infinity and NaN returns an error
+func (f Fixed) BigFloat(prec uint) (*big.Float, error) {
+	if f.IsInf() {
+		return new(big.Float).SetPrec(prec).SetInf(false), nil
+	}
+	r, err := f.BigRat()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetPrec(prec).SetRat(r), nil
+}
+
+// fromBigInt creates a Fixed from a non-negative original integer
+func fromBigInt(x *big.Int) (Fixed, error) {
+	if !x.IsUint64() || x.Uint64() > MAX.fp {
+		return NaN, ErrTooLarge
+	}
+	return Fixed{fp: x.Uint64()}, nil
+}
+
+// fromBigQuo creates a Fixed from the original integer num/den, rounded according to mode
+func fromBigQuo(num, den *big.Int, mode RoundingMode) (Fixed, error) {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if mode.roundUpBig(q, r, den) {
+		q.Add(q, big.NewInt(1))
+	}
+	return fromBigInt(q)
+}
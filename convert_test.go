@@ -0,0 +1,162 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"github.com/shopspring/decimal"
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	f := NewFromString("12345.12345678")
+	d, err := f.ToDecimal()
+	if err != nil {
+		t.Error(err)
+	}
+	if d.String() != "12345.12345678" {
+		t.Error("should be equal", d.String(), "12345.12345678")
+	}
+	f0, err := NewFromDecimal(d, RoundDown)
+	if err != nil || !f0.Equal(f) {
+		t.Error("don't match", f0, f, err)
+	}
+
+	tests := []struct {
+		s    string
+		mode RoundingMode
+		want string
+	}{
+		{"1.234567894", RoundDown, "1.23456789"},
+		{"1.234567894", RoundUp, "1.2345679"},
+		{"1.234567895", RoundHalfUp, "1.2345679"},
+		{"1.234567895", RoundHalfDown, "1.23456789"},
+		{"1.234567895", RoundHalfEven, "1.2345679"},
+		{"1.234567885", RoundHalfEven, "1.23456788"},
+		{"1.2345678850001", RoundHalfEven, "1.23456789"},
+		{"12e3", RoundDown, "12000"},
+		// decided from the exponent alone, without computing 10^|exponent|
+		{"0e2000000000", RoundDown, "0"},
+		{"5e-2000000000", RoundDown, "0"},
+		{"5e-2000000000", RoundHalfUp, "0"},
+		{"5e-2000000000", RoundUp, "0.00000001"},
+		{"0.000000009", RoundHalfUp, "0.00000001"},
+		{"0.0000000009", RoundUp, "0.00000001"},
+		{"0.0000000009", RoundHalfUp, "0"},
+	}
+	for _, test := range tests {
+		f, err := NewFromDecimal(decimal.RequireFromString(test.s), test.mode)
+		if err != nil {
+			t.Error(test.s, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", test.s, test.mode, f, test.want)
+		}
+	}
+
+	if _, err := NewFromDecimal(decimal.RequireFromString("-1"), RoundDown); err == nil {
+		t.Error("should be negative")
+	}
+	if _, err := NewFromDecimal(decimal.RequireFromString("1e11"), RoundDown); err == nil {
+		t.Error("should be too large")
+	}
+	if _, err := NewFromDecimal(decimal.RequireFromString("1e2000000000"), RoundDown); !errors.Is(err, ErrTooLarge) {
+		t.Error("should be too large", err)
+	}
+	if _, err := NaN.ToDecimal(); err == nil {
+		t.Error("NaN should fail")
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	f, err := NewFromBigInt(big.NewInt(12345))
+	if err != nil || f.String() != "12345" {
+		t.Error("should be equal", f, "12345", err)
+	}
+	x, _ := NewFromString("12345.678").BigInt()
+	if x.Int64() != 12345 {
+		t.Error("should be equal", x, 12345)
+	}
+
+	x, _ = NewFromString("1.5").OriginalBigInt()
+	if x.Int64() != 150000000 {
+		t.Error("should be equal", x, 150000000)
+	}
+	f, err = NewFromOriginalBigInt(x)
+	if err != nil || f.String() != "1.5" {
+		t.Error("should be equal", f, "1.5", err)
+	}
+
+	if _, err := NewFromBigInt(big.NewInt(100000000000)); err == nil {
+		t.Error("should be too large")
+	}
+	if _, err := NewFromOriginalBigInt(big.NewInt(-1)); err == nil {
+		t.Error("should be negative")
+	}
+}
+
+func TestBigRatFloat(t *testing.T) {
+	f, err := NewFromBigRat(big.NewRat(2, 3), RoundDown)
+	if err != nil || f.String() != "0.66666666" {
+		t.Error("should be equal", f, "0.66666666", err)
+	}
+	f, _ = NewFromBigRat(big.NewRat(2, 3), RoundHalfUp)
+	if f.String() != "0.66666667" {
+		t.Error("should be equal", f, "0.66666667")
+	}
+	r, _ := NewFromString("0.1").BigRat()
+	if r.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Error("should be equal", r, "1/10")
+	}
+
+	f, err = NewFromBigFloat(big.NewFloat(0.25), RoundDown)
+	if err != nil || f.String() != "0.25" {
+		t.Error("should be equal", f, "0.25", err)
+	}
+	// 0.1 as a float64 is slightly above 0.1
+	f, _ = NewFromBigFloat(big.NewFloat(0.1), RoundUp)
+	if f.String() != "0.10000001" {
+		t.Error("should be equal", f, "0.10000001")
+	}
+	f, _ = NewFromBigFloat(big.NewFloat(0.1), RoundHalfEven)
+	if f.String() != "0.1" {
+		t.Error("should be equal", f, "0.1")
+	}
+
+	x, _ := NewFromString("0.5").BigFloat(53)
+	if x.Acc() != big.Exact || x.String() != "0.5" {
+		t.Error("should be exact", x, x.Acc())
+	}
+	x, _ = NewFromString("0.1").BigFloat(53)
+	if x.Acc() == big.Exact {
+		t.Error("0.1 is not exact in binary")
+	}
+	if f, err := NewFromBigFloat(new(big.Float).SetInf(false), RoundDown); err != nil || !f.IsInf() {
+		t.Error("should be Inf", f, err)
+	}
+	if _, err := NewFromBigFloat(new(big.Float).SetInf(true), RoundDown); err == nil {
+		t.Error("-Inf should fail")
+	}
+	// decided from the exponent alone, without building a rational of 2^30 bits
+	huge := new(big.Float).SetMantExp(big.NewFloat(1), 1<<30)
+	if _, err := NewFromBigFloat(huge, RoundDown); !errors.Is(err, ErrTooLarge) {
+		t.Error("should be too large", err)
+	}
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), -1<<30)
+	if f, err := NewFromBigFloat(tiny, RoundUp); err != nil || f.String() != "0.00000001" {
+		t.Error("should round up", f, err)
+	}
+	if f, err := NewFromBigFloat(tiny, RoundHalfEven); err != nil || !f.Equal(ZERO) {
+		t.Error("should round down", f, err)
+	}
+	if f, err := NewFromBigFloat(new(big.Float).SetMantExp(big.NewFloat(1), 36), RoundDown); err != nil || f.String() != "68719476736" {
+		t.Error("should be equal", f, "68719476736", err)
+	}
+	if x, _ := Inf.BigFloat(53); !x.IsInf() {
+		t.Error("should be Inf", x)
+	}
+	if _, err := NaN.BigFloat(53); err == nil {
+		t.Error("NaN should fail")
+	}
+}
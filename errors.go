@@ -0,0 +1,91 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel errors reported by the package, either directly or wrapped, so they can be tested with errors.Is
+var (
+	// ErrOverflow reports a result too large for a Fixed, or a subtraction that would go below zero
+	ErrOverflow = errors.New("integer overflow")
+	// ErrNegative reports a negative input, which a Fixed cannot represent
+	ErrNegative = errors.New("negative number")
+	// ErrTooLarge reports an input too large for a Fixed
+	ErrTooLarge = errors.New("significand too large")
+	// ErrFormat reports an input that could not be parsed or decoded
+	ErrFormat = errors.New("invalid encoding")
+	// ErrInexact reports that digits would be lost
+	ErrInexact = errors.New("digits would be lost")
+	// ErrNaN reports a NaN where the destination cannot represent it
+	ErrNaN = errors.New("NaN not representable")
+	// ErrInf reports an Inf where the destination or operation cannot accept it
+	ErrInf = errors.New("infinite value")
+	// ErrDivisionByZero reports a division by zero
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrEmpty reports an aggregate with no values to aggregate
+	ErrEmpty = errors.New("no values")
+	// ErrLength reports slices which should be the same length but are not
+	ErrLength = errors.New("length mismatch")
+	// ErrInvalidOperation reports an operation with no defined result, such as zero divided by zero
+	ErrInvalidOperation = errors.New("invalid operation")
+)
+
+// ArithmeticError records a failed arithmetic operation. It is the value of every panic raised by the
+// package, such as those of Add, Sub, Mul, Div and NewFromString, and the error returned by the error
+// reporting arithmetic methods. Err is, or wraps, one of the sentinel errors above, so
+// errors.Is(err, ErrOverflow) matches an *ArithmeticError caused by an overflow
+type ArithmeticError struct {
+	// Op is the name of the operation, for example "Add"
+	Op string
+	// Operands holds the operands in call order, the receiver first. It is empty for an operation taking
+	// no Fixed, such as NewFromFloat, whose input is described by Err instead
+	Operands []Fixed
+	// Err is the reason for the failure
+	Err error
+}
+
+func (e *ArithmeticError) Error() string {
+	var b strings.Builder
+	b.WriteString("fixed: ")
+	b.WriteString(e.Op)
+	if len(e.Operands) > 0 {
+		b.WriteByte('(')
+		for i, f := range e.Operands {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(f.String())
+		}
+		b.WriteByte(')')
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *ArithmeticError) Unwrap() error {
+	return e.Err
+}
+
+// arithmeticError returns an *ArithmeticError for op failing with err, or nil if err is nil
+func arithmeticError(op string, err error, operands ...Fixed) error {
+	if err == nil {
+		return nil
+	}
+	return &ArithmeticError{Op: op, Operands: operands, Err: err}
+}
+
+// renameOp sets the Op of an *ArithmeticError returned by the operation a public method is built on to
+// the name of that method, and its Operands to operands if any are given. Any other err is returned unchanged
+func renameOp(err error, op string, operands ...Fixed) error {
+	if err, ok := err.(*ArithmeticError); ok {
+		err.Op = op
+		if len(operands) > 0 {
+			err.Operands = operands
+		}
+	}
+	return err
+}
@@ -0,0 +1,210 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// names of the exchange filters checked by SymbolFilters
+const (
+	FilterPrice        = "PRICE_FILTER"
+	FilterLotSize      = "LOT_SIZE"
+	FilterMinNotional  = "MIN_NOTIONAL"
+	FilterPercentPrice = "PERCENT_PRICE"
+)
+
+// PriceFilter is the PRICE_FILTER rule. A zero field disables its check
+type PriceFilter struct {
+	MinPrice Fixed
+	MaxPrice Fixed
+	// TickSize is the grid a price must lie on, counting from MinPrice
+	TickSize Fixed
+}
+
+// LotSize is the LOT_SIZE rule. A zero field disables its check
+type LotSize struct {
+	MinQty Fixed
+	MaxQty Fixed
+	// StepSize is the grid a quantity must lie on, counting from MinQty
+	StepSize Fixed
+}
+
+// PercentPrice is the PERCENT_PRICE rule, bounding a price to a band around a reference price such as
+// the average price. A zero field disables its check
+type PercentPrice struct {
+	MultiplierUp   Fixed
+	MultiplierDown Fixed
+}
+
+// SymbolFilters holds the trading rules of a symbol which an order must satisfy
+type SymbolFilters struct {
+	Price PriceFilter
+	Lot   LotSize
+	// MinNotional is the smallest price * quantity allowed, or zero to disable the check
+	MinNotional  Fixed
+	PercentPrice PercentPrice
+}
+
+// Violation describes one broken rule: Value, the price, quantity or notional checked, is not allowed
+// by Limit, the value of the Rule field of Filter
+type Violation struct {
+	// Filter is the filter broken, such as FilterPrice
+	Filter string
+	// Rule is the field of the filter broken, named as the exchange names it, such as "tickSize"
+	Rule  string
+	Value Fixed
+	Limit Fixed
+}
+
+func (v Violation) String() string {
+	var relation string
+	switch v.Rule {
+	case "minPrice", "minQty", "minNotional", "multiplierDown":
+		relation = " is below "
+	case "maxPrice", "maxQty", "multiplierUp":
+		relation = " is above "
+	default:
+		relation = " is off the grid of "
+	}
+	return v.Filter + ": " + v.Value.String() + relation + v.Rule + " " + v.Limit.String()
+}
+
+// FilterError is the error returned for an order breaking any of the rules of a SymbolFilters
+type FilterError struct {
+	Violations []Violation
+}
+
+func (e *FilterError) Error() string {
+	s := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		s[i] = v.String()
+	}
+	return "fixed: order rejected: " + strings.Join(s, "; ")
+}
+
+// Validate checks an order of quantity at price against the filters, returning a *FilterError listing
+// every rule broken, or nil. reference is the price the PERCENT_PRICE band is centred on; if it is zero
+// that check is skipped. An error is also returned if price or quantity is NaN or Inf
+func (s *SymbolFilters) Validate(price, quantity, reference Fixed) error {
+	if err := filterOperands("SymbolFilters.Validate", price, quantity); err != nil {
+		return err
+	}
+	var violations []Violation
+	add := func(filter, rule string, value, limit Fixed) {
+		violations = append(violations, Violation{Filter: filter, Rule: rule, Value: value, Limit: limit})
+	}
+
+	p := s.Price
+	switch {
+	case p.MinPrice.fp != 0 && price.fp < p.MinPrice.fp:
+		add(FilterPrice, "minPrice", price, p.MinPrice)
+	case p.MaxPrice.fp != 0 && price.fp > p.MaxPrice.fp:
+		add(FilterPrice, "maxPrice", price, p.MaxPrice)
+	}
+	if !onGrid(price, p.MinPrice, p.TickSize) {
+		add(FilterPrice, "tickSize", price, p.TickSize)
+	}
+
+	l := s.Lot
+	switch {
+	case l.MinQty.fp != 0 && quantity.fp < l.MinQty.fp:
+		add(FilterLotSize, "minQty", quantity, l.MinQty)
+	case l.MaxQty.fp != 0 && quantity.fp > l.MaxQty.fp:
+		add(FilterLotSize, "maxQty", quantity, l.MaxQty)
+	}
+	if !onGrid(quantity, l.MinQty, l.StepSize) {
+		add(FilterLotSize, "stepSize", quantity, l.StepSize)
+	}
+
+	if s.MinNotional.fp != 0 {
+		if cmpProducts(price, quantity, s.MinNotional, ONE) < 0 {
+			notional, _ := price.MulSat(quantity)
+			add(FilterMinNotional, "minNotional", notional, s.MinNotional)
+		}
+	}
+
+	// the band is compared exactly; the limit reported is rounded towards the allowed side
+	if pp := s.PercentPrice; reference.fp != 0 && !reference.IsNaN() && !reference.IsInf() {
+		if up := pp.MultiplierUp; up.fp != 0 && cmpProducts(price, ONE, reference, up) > 0 {
+			limit, _ := reference.MulSat(up)
+			add(FilterPercentPrice, "multiplierUp", price, limit)
+		}
+		if down := pp.MultiplierDown; down.fp != 0 && cmpProducts(price, ONE, reference, down) < 0 {
+			limit, _ := reference.MulDiv(down, ONE, RoundUp)
+			add(FilterPercentPrice, "multiplierDown", price, limit)
+		}
+	}
+
+	if violations == nil {
+		return nil
+	}
+	return &FilterError{Violations: violations}
+}
+
+// Adjust moves an order onto the grids of the filters and within their bounds where that can be done
+// without trading more than asked: price is rounded to the tick size according to mode and clamped to
+// the price range, and quantity is rounded down to the step size and clamped to the maximum quantity.
+// It returns the adjusted price and quantity, and the result of validating them, which reports the rules
+// adjusting cannot satisfy, such as a quantity below the minimum
+func (s *SymbolFilters) Adjust(price, quantity, reference Fixed, mode RoundingMode) (Fixed, Fixed, error) {
+	if err := filterOperands("SymbolFilters.Adjust", price, quantity); err != nil {
+		return price, quantity, err
+	}
+	p := s.Price
+	price = snap(price, p.MinPrice, p.TickSize, mode)
+	if p.MinPrice.fp != 0 && price.fp < p.MinPrice.fp {
+		price = p.MinPrice
+	}
+	if p.MaxPrice.fp != 0 && price.fp > p.MaxPrice.fp {
+		price = snap(p.MaxPrice, p.MinPrice, p.TickSize, RoundDown)
+	}
+
+	l := s.Lot
+	quantity = snap(quantity, l.MinQty, l.StepSize, RoundDown)
+	if l.MaxQty.fp != 0 && quantity.fp > l.MaxQty.fp {
+		quantity = snap(l.MaxQty, l.MinQty, l.StepSize, RoundDown)
+	}
+	return price, quantity, s.Validate(price, quantity, reference)
+}
+
+// onGrid reports whether f is base plus a multiple of step, or step is zero
+func onGrid(f, base, step Fixed) bool {
+	return step.fp == 0 || f.fp < base.fp || (f.fp-base.fp)%step.fp == 0
+}
+
+// snap rounds f to base plus a multiple of step according to mode, leaving it unchanged if step is zero,
+// f is below base or rounding up would overflow
+func snap(f, base, step Fixed, mode RoundingMode) Fixed {
+	if step.fp == 0 || f.fp < base.fp {
+		return f
+	}
+	offset, err := Fixed{fp: f.fp - base.fp}.RoundToStep(step, mode)
+	if err != nil {
+		return f
+	}
+	result, ok := base.add(offset)
+	if !ok {
+		return f
+	}
+	return result
+}
+
+// cmpProducts compares a*b with c*d exactly in 128 bits
+func cmpProducts(a, b, c, d Fixed) int {
+	hi, lo := bits.Mul64(a.fp, b.fp)
+	hi0, lo0 := bits.Mul64(c.fp, d.fp)
+	return uint128{hi: hi, lo: lo}.cmp(uint128{hi: hi0, lo: lo0})
+}
+
+// filterOperands rejects a NaN or Inf price or quantity
+func filterOperands(op string, price, quantity Fixed) error {
+	switch {
+	case price.IsNaN() || quantity.IsNaN():
+		return arithmeticError(op, ErrNaN, price, quantity)
+	case price.IsInf() || quantity.IsInf():
+		return arithmeticError(op, ErrInf, price, quantity)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func testFilters() *SymbolFilters {
+	return &SymbolFilters{
+		Price:        PriceFilter{MinPrice: NewFromString("0.01"), MaxPrice: NewFromString("1000"), TickSize: NewFromString("0.01")},
+		Lot:          LotSize{MinQty: NewFromString("0.001"), MaxQty: NewFromString("100"), StepSize: NewFromString("0.001")},
+		MinNotional:  TEN,
+		PercentPrice: PercentPrice{MultiplierUp: NewFromString("1.1"), MultiplierDown: NewFromString("0.9")},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := testFilters()
+	reference := NewFromString("100")
+	if err := s.Validate(NewFromString("101.25"), NewFromString("0.5"), reference); err != nil {
+		t.Error("should be valid", err)
+	}
+
+	err := s.Validate(NewFromString("120.005"), NewFromString("0.0505"), reference)
+	var fe *FilterError
+	if !errors.As(err, &fe) {
+		t.Fatal("should be rejected", err)
+	}
+	want := []string{
+		"PRICE_FILTER: 120.005 is off the grid of tickSize 0.01",
+		"LOT_SIZE: 0.0505 is off the grid of stepSize 0.001",
+		"MIN_NOTIONAL: 6.0602525 is below minNotional 10",
+		"PERCENT_PRICE: 120.005 is above multiplierUp 110",
+	}
+	if len(fe.Violations) != len(want) {
+		t.Fatal("should be equal", fe.Violations, want)
+	}
+	for i, v := range fe.Violations {
+		if v.String() != want[i] {
+			t.Error("should be equal", v.String(), want[i])
+		}
+	}
+	if fe.Violations[2].Filter != FilterMinNotional || fe.Violations[2].Rule != "minNotional" {
+		t.Error("should be structured", fe.Violations[2])
+	}
+
+	// the band is compared exactly, not against a truncated limit
+	band := SymbolFilters{PercentPrice: PercentPrice{MultiplierUp: NewFromString("1.1"), MultiplierDown: NewFromString("0.9")}}
+	reference = NewFromString("1.23456789")
+	err = band.Validate(NewFromString("1.1111111"), ONE, reference)
+	if !errors.As(err, &fe) || fe.Violations[0].String() != "PERCENT_PRICE: 1.1111111 is below multiplierDown 1.11111111" {
+		t.Error("should be below the band", err)
+	}
+	err = band.Validate(NewFromString("1.35802468"), ONE, reference)
+	if !errors.As(err, &fe) || fe.Violations[0].Rule != "multiplierUp" {
+		t.Error("should be above the band", err)
+	}
+	if err := band.Validate(NewFromString("1.11111111"), ONE, reference); err != nil {
+		t.Error("should be valid", err)
+	}
+	if err := band.Validate(NewFromString("1.35802467"), ONE, reference); err != nil {
+		t.Error("should be valid", err)
+	}
+
+	// disabled checks and a missing reference price
+	var none SymbolFilters
+	if err := none.Validate(NewFromString("0.0000001"), MAX, ZERO); err != nil {
+		t.Error("should be valid", err)
+	}
+	if err := s.Validate(NaN, ONE, reference); !errors.Is(err, ErrNaN) {
+		t.Error("should reject NaN", err)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	s := testFilters()
+	reference := NewFromString("100")
+
+	price, quantity, err := s.Adjust(NewFromString("101.257"), NewFromString("0.12345"), reference, RoundHalfUp)
+	if err != nil || price.String() != "101.26" || quantity.String() != "0.123" {
+		t.Error("should be equal", price, quantity, "101.26", "0.123", err)
+	}
+	price, quantity, err = s.Adjust(NewFromString("5000"), NewFromString("250"), ZERO, RoundUp)
+	if err != nil || price.String() != "1000" || quantity.String() != "100" {
+		t.Error("should be clamped", price, quantity, err)
+	}
+
+	// a quantity too small cannot be adjusted without trading more
+	_, quantity, err = s.Adjust(NewFromString("100"), NewFromString("0.0001"), reference, RoundDown)
+	var fe *FilterError
+	if !errors.As(err, &fe) || fe.Violations[0].Rule != "minQty" || quantity.String() != "0.0001" {
+		t.Error("should report minQty", quantity, err)
+	}
+}
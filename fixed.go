@@ -4,22 +4,24 @@ package fixed
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/bits"
 	"strconv"
 	"strings"
 	"github.com/shopspring/decimal"
 )
 
-// Fixed is a fixed precision 38.24 number (supports 10.8 digits). It supports NaN.
+// Fixed is a fixed precision 38.24 number (supports 10.8 digits). It supports NaN and positive infinity (Inf).
 type Fixed struct {
 	fp uint64
 }
 
 // the following constants can be changed to configure a different number of decimal places - these are
-// the only required changes. only 18 significant digits are supported due to NaN
+// the only required changes. only 18 significant digits are supported due to NaN. the six largest
+// encodings, from reserved up, are reserved for the NaNs carrying a NaNReason, Inf and NaN, all of them
+// well above MAX
 
 const (
 	nPlaces = 8
@@ -27,10 +29,14 @@ const (
 	zeros = "00000000"
 	max = float64(99999999999.99999)
 	nan = uint64(1<<64 - 1)
+	inf = nan - 1
+	// encodings from reserved up to inf are NaNs carrying a NaNReason, see NewNaN
+	reserved = inf - uint64(maxNaNReason)
 )
 
 var (
 	NaN   = Fixed{fp: nan}
+	Inf   = Fixed{fp: inf}
 	ZERO  = Fixed{fp: 0}
 	ONE   = Fixed{fp: 1e8}
 	TWO   = Fixed{fp: 2e8}
@@ -45,35 +51,43 @@ var (
 	MAX   = Fixed{fp: 9999999999999999999}
 )
 
-var errOverflow = errors.New("integer overflow")
-var errNegativeNum = errors.New("negative number")
-var errTooLarge = errors.New("significand too large")
-var errFormat = errors.New("invalid encoding")
+// pow10 holds the powers of ten representable in an uint64
+var pow10 = [...]uint64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
 
-// NewFromString creates a new Fixed from a string, returning NaN if the string could not be parsed
+// NewFromString creates a new Fixed from a string. It panics with an *ArithmeticError if the string could not
+// be parsed; use NewFromStringErr to handle the error
 func NewFromString(s string) Fixed {
 	f, err := NewFromStringErr(s)
 	if err != nil {
-		panic(fmt.Sprintf("newSErr(%s) err: %s", s, err))
+		panic(&ArithmeticError{Op: "NewFromString", Err: err})
 	}
 	return f
 }
 
-// NewFromStringErr creates a new Fixed from a string, returning NaN, and error if the string could not be parsed
+// NewFromStringErr creates a new Fixed from a string, returning a NaN with reason NaNParse, and error if the string could not be parsed
 func NewFromStringErr(s string) (Fixed, error) {
 	if strings.HasPrefix(s, "-") {
-		return NaN, errNegativeNum
+		return parseError(s, ErrNegative)
 	}
 	if strings.ContainsAny(s, "eE") {
 		f, err := strconv.ParseFloat(s, 64)
 		if err != nil {
-			return NaN, err
+			return parseError(s, ErrFormat)
+		}
+		if f >= max || f < 0 {
+			return parseError(s, ErrTooLarge)
 		}
 		return NewFromFloat(f), nil
 	}
 	if "NaN" == s {
 		return NaN, nil
 	}
+	if "Inf" == s {
+		return Inf, nil
+	}
 	period := strings.Index(s, ".")
 	var i uint64
 	var f uint64
@@ -87,14 +101,19 @@ func NewFromStringErr(s string) (Fixed, error) {
 		f, err = strconv.ParseUint(fs[0:nPlaces], 10, 64)
 	}
 	if err != nil {
-		return NaN, err
+		return parseError(s, ErrFormat)
 	}
 	if float64(i) > max {
-		return NaN, errTooLarge
+		return parseError(s, ErrTooLarge)
 	}
 	return Fixed{fp: i*scale + f}, nil
 }
 
+// parseError returns a NaN with reason NaNParse and err wrapped with the string that failed to parse
+func parseError(s string, err error) (Fixed, error) {
+	return NewNaN(NaNParse), fmt.Errorf("parsing %q: %w", s, err)
+}
+
 func maxInt(a, b int) int {
 	if a > b {
 		return a
@@ -102,16 +121,32 @@ func maxInt(a, b int) int {
 	return b
 }
 
-// NewFromFloat creates a Fixed from an float64, rounding at the 8th decimal place
+// NewFromFloat creates a Fixed from an float64, rounding at the 8th decimal place. Positive infinity becomes Inf.
+// It panics with an *ArithmeticError if f is negative or too large
 func NewFromFloat(f float64) Fixed {
+	f0, err := newFromFloat(f)
+	if err != nil {
+		panic(&ArithmeticError{Op: "NewFromFloat", Err: fmt.Errorf("%v: %w", f, err)})
+	}
+	return f0
+}
+
+// newFromFloat computes NewFromFloat, returning a bare sentinel error so callers can report it as their own
+func newFromFloat(f float64) (Fixed, error) {
 	if math.IsNaN(f) {
-		return Fixed{fp: nan}
+		return Fixed{fp: nan}, nil
+	}
+	if math.IsInf(f, 1) {
+		return Inf, nil
 	}
-	if f >= max || f < 0 {
-		panic(errOverflow)
+	if f < 0 {
+		return NaN, ErrNegative
+	}
+	if f >= max {
+		return NaN, ErrOverflow
 	}
 	intPart := decimal.NewFromFloat(f).Mul(decimal.NewFromFloat(float64(scale))).IntPart()
-	return Fixed{fp: uint64(intPart)}
+	return Fixed{fp: uint64(intPart)}, nil
 }
 
 // NewFromUint creates a Fixed from an uint64
@@ -121,18 +156,58 @@ func NewFromUint(i uint64) Fixed {
 
 
 // NewFromUint creates a Fixed for an integer, moving the decimal point n places to the left
-// For example, NewFromUint(123,1) becomes 12.3. If n > 8, the value is truncated
+// For example, NewFromUint(123,1) becomes 12.3. If n > 8, the value is truncated. Overflow is not
+// checked, use NewFromUintMantissa to detect overflow and lost digits
 func NewFromUintWithExponent(i uint64, n uint) Fixed {
 	if n > nPlaces {
-		i = i / uint64(math.Pow10(int(n-nPlaces)))
+		if n-nPlaces >= uint(len(pow10)) {
+			return ZERO
+		}
+		i = i / pow10[n-nPlaces]
 		n = nPlaces
 	}
 
-	i = i * uint64(math.Pow10(int(nPlaces-n)))
+	i = i * pow10[nPlaces-n]
 
 	return Fixed{fp: i}
 }
 
+// NewFromMantissa creates a Fixed equal to m * 10^exp, as sent by exchange APIs as (mantissa, exponent)
+// pairs. For example NewFromMantissa(123, -1) becomes 12.3 and NewFromMantissa(5, 3) becomes 5000.
+// An error is returned if m is negative, the result is too large, or digits beyond the 8th decimal
+// place would be lost
+func NewFromMantissa(m int64, exp int32) (Fixed, error) {
+	if m < 0 {
+		return NaN, ErrNegative
+	}
+	return NewFromUintMantissa(uint64(m), exp)
+}
+
+// NewFromUintMantissa creates a Fixed equal to m * 10^exp, with the same checks as NewFromMantissa
+func NewFromUintMantissa(m uint64, exp int32) (Fixed, error) {
+	if m == 0 {
+		return ZERO, nil
+	}
+	shift := int(exp) + nPlaces
+	if shift < 0 {
+		if -shift >= len(pow10) {
+			return NaN, ErrInexact
+		}
+		if m%pow10[-shift] != 0 {
+			return NaN, ErrInexact
+		}
+		return Fixed{fp: m / pow10[-shift]}, nil
+	}
+	if shift >= len(pow10) {
+		return NaN, ErrTooLarge
+	}
+	hi, fp := bits.Mul64(m, pow10[shift])
+	if hi != 0 || fp > MAX.fp {
+		return NaN, ErrTooLarge
+	}
+	return Fixed{fp: fp}, nil
+}
+
 
 // NewFromOriginal creates a Fixed for an fixed original integer, moving the decimal point n places to the left
 // For example, NewFromOriginal(123) becomes 0.00000123.
@@ -140,8 +215,14 @@ func NewFromOriginal(i uint64) Fixed {
 	return NewFromUintWithExponent(i , nPlaces)
 }
 
+// IsNaN reports whether f is NaN, whatever its NaNReason
 func (f Fixed) IsNaN() bool {
-	return f.fp == nan
+	return f.fp >= reserved && f.fp != inf
+}
+
+// IsInf reports whether f is Inf
+func (f Fixed) IsInf() bool {
+	return f.fp == inf
 }
 
 func (f Fixed) IsZero() bool {
@@ -161,83 +242,265 @@ func (f Fixed) Sign() int {
 	return f.Cmp(ZERO)
 }
 
-// Float converts the Fixed to a float64
+// Float converts the Fixed to a float64. Inf becomes positive infinity
 func (f Fixed) Float() float64 {
 	if f.IsNaN() {
 		return math.NaN()
 	}
+	if f.IsInf() {
+		return math.Inf(1)
+	}
 	return float64(f.fp) / float64(scale)
 }
 
-// Add adds f0 to f producing a Fixed. If either operand is NaN, NaN is returned
+// Add adds f0 to f producing a Fixed. If either operand is NaN, that NaN is returned, otherwise if either is
+// Inf, Inf is returned. Overflow, a result above MAX, panics with an *ArithmeticError
 func (f Fixed) Add(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if result, done, _ := special('+', f, f0); done {
+		return result
 	}
-
-	var result uint64
-	result = f.fp + f0.fp
-
-	if (result > f.fp) != (f0.fp > 0) {
-		panic(errOverflow)
+	result, ok := f.add(f0)
+	if !ok {
+		panic(&ArithmeticError{Op: "Add", Operands: []Fixed{f, f0}, Err: ErrOverflow})
 	}
-	return Fixed{fp: result}
+	return result
 }
 
-// Sub subtracts f0 from f producing a Fixed. If either operand is NaN, NaN is returned
+// Sub subtracts f0 from f producing a Fixed. If either operand is NaN, that NaN is returned. Inf minus a finite
+// value is Inf, and Inf minus Inf is NaN. Overflow, including a negative result such as a finite value
+// minus Inf, panics with an *ArithmeticError
 func (f Fixed) Sub(f0 Fixed) Fixed {
-	// check overflow
-	if f.LessThan(f0) {
-		panic(errOverflow)
+	if result, done, err := special('-', f, f0); done {
+		if err != nil {
+			panic(&ArithmeticError{Op: "Sub", Operands: []Fixed{f, f0}, Err: err})
+		}
+		return result
 	}
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	result, ok := f.sub(f0)
+	if !ok {
+		panic(&ArithmeticError{Op: "Sub", Operands: []Fixed{f, f0}, Err: ErrOverflow})
 	}
-	return Fixed{fp: f.fp - f0.fp}
+	return result
 }
 
-// Mul multiplies f by f0 returning a Fixed. If either operand is NaN, NaN is returned
+// Mul multiplies f by f0 returning a Fixed. If either operand is NaN, that NaN is returned. Inf times zero is
+// NaN and Inf times anything else is Inf. Overflow, a result above MAX, panics with an *ArithmeticError
 func (f Fixed) Mul(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if result, done, _ := special('*', f, f0); done {
+		return result
+	}
+	result, ok := f.mul(f0)
+	if !ok {
+		panic(&ArithmeticError{Op: "Mul", Operands: []Fixed{f, f0}, Err: ErrOverflow})
 	}
+	return result
+}
 
-	fp_a := f.fp / scale
-	fp_b := f.fp % scale
+// add returns f+f0 for finite operands, reporting false on overflow, a result above MAX
+func (f Fixed) add(f0 Fixed) (Fixed, bool) {
+	result := f.fp + f0.fp
+	if (result > f.fp) != (f0.fp > 0) || result > MAX.fp {
+		return NaN, false
+	}
+	return Fixed{fp: result}, true
+}
 
-	fp0_a := f0.fp / scale
-	fp0_b := f0.fp % scale
+// sub returns f-f0 for finite operands, reporting false if the result would be negative
+func (f Fixed) sub(f0 Fixed) (Fixed, bool) {
+	if f.fp < f0.fp {
+		return NaN, false
+	}
+	return Fixed{fp: f.fp - f0.fp}, true
+}
+
+// mul returns f*f0 truncated to 8 places for finite operands, reporting false on overflow, a result above MAX
+func (f Fixed) mul(f0 Fixed) (Fixed, bool) {
+	hi, lo := bits.Mul64(f.fp, f0.fp)
+	if hi == 0 {
+		return Fixed{fp: lo / scale}, true
+	}
+	if hi >= scale {
+		return NaN, false
+	}
+	result, _ := bits.Div64(hi, lo, scale)
+	if result > MAX.fp {
+		return NaN, false
+	}
+	return Fixed{fp: result}, true
+}
+
+// special returns the result of op ('+', '-', '*' or '/') when either operand is NaN or Inf, reporting
+// false if both are finite. A NaN operand is returned as is, keeping its NaNReason, and an undefined
+// result is a NaN with reason NaNInvalid. The error is ErrOverflow when a finite value minus Inf would
+// be negative
+func special(op byte, f, f0 Fixed) (result Fixed, done bool, err error) {
+	switch {
+	case f.IsNaN():
+		return f, true, nil
+	case f0.IsNaN():
+		return f0, true, nil
+	case !f.IsInf() && !f0.IsInf():
+		return NaN, false, nil
+	}
+	invalid := NewNaN(NaNInvalid)
+	switch op {
+	case '-':
+		if f0.IsInf() {
+			if f.IsInf() {
+				return invalid, true, nil
+			}
+			return invalid, true, ErrOverflow
+		}
+	case '*':
+		if f.fp == 0 || f0.fp == 0 {
+			return invalid, true, nil
+		}
+	case '/':
+		if f.IsInf() && f0.IsInf() {
+			return invalid, true, nil
+		}
+		if f0.IsInf() {
+			return ZERO, true, nil
+		}
+	}
+	return Inf, true, nil
+}
 
-	var result uint64
+// Div divides f by f0 returning a Fixed. If either operand is NaN, that NaN is returned. Inf divided by a
+// finite value is Inf, a finite value divided by Inf is zero and Inf divided by Inf is NaN. Dividing a
+// non-zero value by zero returns Inf, and zero by zero returns a NaN with reason NaNDivisionByZero. It panics
+// with an *ArithmeticError if the quotient is too large
+func (f Fixed) Div(f0 Fixed) Fixed {
+	if result, done, _ := special('/', f, f0); done {
+		return result
+	}
+	if f0.fp == 0 {
+		if f.fp == 0 {
+			return NewNaN(NaNDivisionByZero)
+		}
+		return Inf
+	}
+	result, err := newFromFloat(f.Float() / f0.Float())
+	if err != nil {
+		panic(&ArithmeticError{Op: "Div", Operands: []Fixed{f, f0}, Err: err})
+	}
+	return result
+}
 
-	if fp0_a != 0 {
-		result = fp_a*fp0_a*scale + fp_b*fp0_a
+// MulDiv returns f*f0/f1 computed with a 128 bit intermediate and rounded once according to mode, so it
+// neither truncates twice nor overflows when only the intermediate product is large. An error is
+// returned if f1 is zero, an operand is Inf or the result is too large. If any operand is NaN, NaN is returned
+func (f Fixed) MulDiv(f0, f1 Fixed, mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() || f0.IsNaN() || f1.IsNaN() {
+		return NaN, nil
+	}
+	if f.IsInf() || f0.IsInf() || f1.IsInf() {
+		return NaN, arithmeticError("MulDiv", ErrInf, f, f0, f1)
 	}
-	if fp0_b != 0 {
-		result = result + (fp_a * fp0_b) + ((fp_b)*fp0_b)/scale
+	if f1.fp == 0 {
+		return NaN, arithmeticError("MulDiv", ErrDivisionByZero, f, f0, f1)
 	}
+	// (f.fp/10^8 * f0.fp/10^8) / (f1.fp/10^8) * 10^8 == f.fp*f0.fp/f1.fp
+	q, _, ok := mulDivRound(f.fp, f0.fp, f1.fp, mode)
+	if !ok || q > MAX.fp {
+		return NaN, arithmeticError("MulDiv", ErrOverflow, f, f0, f1)
+	}
+	return Fixed{fp: q}, nil
+}
 
-	// check overflow
-	if (fp_a >= 1 && fp_b >= 1) && (fp0_a > 1 && fp0_b > 1) {
-		if result/f.fp != fp0_a {
-			panic(errOverflow)
+// mulDivRound returns a*b/d computed with a 128 bit intermediate and rounded according to mode, and
+// whether non-zero digits were discarded. It reports false if the result does not fit in 64 bits
+func mulDivRound(a, b, d uint64, mode RoundingMode) (q uint64, inexact, ok bool) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= d {
+		return 0, false, false
+	}
+	q, r := bits.Div64(hi, lo, d)
+	if mode.roundUp(q, r, d) {
+		if q == 1<<64-1 {
+			return 0, false, false
 		}
+		q++
 	}
-	return Fixed{fp: result}
+	return q, r != 0, true
 }
 
-// Div divides f by f0 returning a Fixed. If either operand is NaN, NaN is returned
-func (f Fixed) Div(f0 Fixed) Fixed {
+// Quo returns the integer quotient of f divided by f0, i.e. how many whole f0 fit in f. It is computed
+// exactly in integer arithmetic. An error is returned if f0 is zero, an operand is Inf or the quotient is too large.
+// If either operand is NaN, NaN is returned
+func (f Fixed) Quo(f0 Fixed) (Fixed, error) {
+	q, _, err := f.DivMod(f0)
+	return q, renameOp(err, "Quo")
+}
+
+// Rem returns the remainder of f divided by f0, i.e. f - f.Quo(f0)*f0. An error is returned if f0 is
+// zero or an operand is Inf. If either operand is NaN, NaN is returned
+func (f Fixed) Rem(f0 Fixed) (Fixed, error) {
+	if f.IsNaN() || f0.IsNaN() {
+		return NaN, nil
+	}
+	if f.IsInf() || f0.IsInf() {
+		return NaN, arithmeticError("Rem", ErrInf, f, f0)
+	}
+	if f0.fp == 0 {
+		return NaN, arithmeticError("Rem", ErrDivisionByZero, f, f0)
+	}
+	return Fixed{fp: f.fp % f0.fp}, nil
+}
+
+// DivMod returns both the integer quotient and the remainder of f divided by f0, as Quo and Rem
+func (f Fixed) DivMod(f0 Fixed) (q, r Fixed, err error) {
 	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+		return NaN, NaN, nil
+	}
+	if f.IsInf() || f0.IsInf() {
+		return NaN, NaN, arithmeticError("DivMod", ErrInf, f, f0)
+	}
+	if f0.fp == 0 {
+		return NaN, NaN, arithmeticError("DivMod", ErrDivisionByZero, f, f0)
+	}
+	n := f.fp / f0.fp
+	if n > MAX.fp/scale {
+		return NaN, NaN, arithmeticError("DivMod", ErrOverflow, f, f0)
+	}
+	return Fixed{fp: n * scale}, Fixed{fp: f.fp % f0.fp}, nil
+}
+
+// QuoRem returns the quotient of f divided by f0 truncated to places decimal places, and the remainder
+// r such that f == q*f0 + r. An error is returned if f0 is zero, an operand is Inf, the quotient is too large, or the
+// remainder has more than 8 decimal places. If either operand is NaN, NaN is returned
+func (f Fixed) QuoRem(f0 Fixed, places int) (q, r Fixed, err error) {
+	if f.IsNaN() || f0.IsNaN() {
+		return NaN, NaN, nil
+	}
+	if f.IsInf() || f0.IsInf() {
+		return NaN, NaN, arithmeticError("QuoRem", ErrInf, f, f0)
+	}
+	if f0.fp == 0 {
+		return NaN, NaN, arithmeticError("QuoRem", ErrDivisionByZero, f, f0)
+	}
+	if places < 0 || places > nPlaces {
+		return NaN, NaN, arithmeticError("QuoRem", ErrFormat, f, f0)
+	}
+	// f * 10^places == n * f0 + rem, so r == rem / 10^places
+	hi, lo := bits.Mul64(f.fp, pow10[places])
+	if hi >= f0.fp {
+		return NaN, NaN, arithmeticError("QuoRem", ErrOverflow, f, f0)
 	}
-	return NewFromFloat(f.Float() / f0.Float())
+	n, rem := bits.Div64(hi, lo, f0.fp)
+	if n > MAX.fp/pow10[nPlaces-places] {
+		return NaN, NaN, arithmeticError("QuoRem", ErrOverflow, f, f0)
+	}
+	if rem%pow10[places] != 0 {
+		return NaN, NaN, arithmeticError("QuoRem", ErrInexact, f, f0)
+	}
+	return Fixed{fp: n * pow10[nPlaces-places]}, Fixed{fp: rem / pow10[places]}, nil
 }
 
-// Round returns a rounded (half-up, away from zero) to n decimal places
+// Round returns a rounded (half-up, away from zero) to n decimal places. NaN and Inf are returned unchanged
 func (f Fixed) Round(n int) Fixed {
-	if f.IsNaN() {
-		return NaN
+	if f.IsNaN() || f.IsInf() {
+		return f
 	}
 
 	round := .5
@@ -246,7 +509,11 @@ func (f Fixed) Round(n int) Fixed {
 	f0 = f0*math.Pow10(n) + round
 	f0 = float64(int(f0)) / math.Pow10(n)
 
-	return NewFromFloat(float64(f.UInt()) + f0)
+	result, err := newFromFloat(float64(f.UInt()) + f0)
+	if err != nil {
+		panic(&ArithmeticError{Op: "Round", Operands: []Fixed{f}, Err: err})
+	}
+	return result
 }
 
 // Equal returns true if the f == f0. If either operand is NaN, false is returned. Use IsNaN() to test for NaN
@@ -279,7 +546,8 @@ func (f Fixed) LessThanOrEqual(f0 Fixed) bool {
 	return cmp == -1 || cmp == 0
 }
 
-// Cmp compares two Fixed. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1. If both are NaN, return 0. If f is NaN, return 1. If f0 is NaN, return -1
+// Cmp compares two Fixed. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1. If both are NaN, return 0. If f is NaN, return 1. If f0 is NaN, return -1.
+// Inf is greater than every other value except NaN, and equal to itself
 func (f Fixed) Cmp(f0 Fixed) int {
 	if f.IsNaN() && f0.IsNaN() {
 		return 0
@@ -335,9 +603,12 @@ func (f Fixed) toStr() (string, int) {
 	if fp == 0 {
 		return "0." + zeros, 1
 	}
-	if fp == nan {
+	if f.IsNaN() {
 		return "NaN", -1
 	}
+	if fp == inf {
+		return "Inf", -1
+	}
 
 	b := make([]byte, 24)
 	b = itoa(b, fp)
@@ -361,17 +632,17 @@ func itoa(buf []byte, val uint64) []byte {
 	return buf[i:]
 }
 
-// UInt return the integer portion of the Fixed, or 0 if NaN
+// UInt return the integer portion of the Fixed, or 0 if NaN or Inf
 func (f Fixed) UInt() uint64 {
-	if f.IsNaN() {
+	if f.IsNaN() || f.IsInf() {
 		return 0
 	}
 	return f.fp / scale
 }
 
-// Frac return the fractional portion of the Fixed, or NaN if NaN
+// Frac return the fractional portion of the Fixed, or NaN if NaN or Inf
 func (f Fixed) Frac() float64 {
-	if f.IsNaN() {
+	if f.IsNaN() || f.IsInf() {
 		return math.NaN()
 	}
 	return float64(f.fp%scale) / float64(scale)
@@ -382,11 +653,52 @@ func (f Fixed) Original() uint64 {
 	return f.fp
 }
 
+// Mantissa returns the integer m such that f == m * 10^exp, for example NewFromString("12.3").Mantissa(-2)
+// returns 1230. An error is returned if f is NaN or Inf, m does not fit an int64, or f has digits below 10^exp
+func (f Fixed) Mantissa(exp int32) (int64, error) {
+	m, err := f.UintMantissa(exp)
+	if err != nil {
+		return 0, err
+	}
+	if m > math.MaxInt64 {
+		return 0, ErrTooLarge
+	}
+	return int64(m), nil
+}
+
+// UintMantissa returns the integer m such that f == m * 10^exp, with the same checks as Mantissa
+func (f Fixed) UintMantissa(exp int32) (uint64, error) {
+	if f.IsNaN() {
+		return 0, ErrNaN
+	}
+	if f.IsInf() {
+		return 0, ErrInf
+	}
+	if f.fp == 0 {
+		return 0, nil
+	}
+	shift := -int(exp) - nPlaces
+	if shift < 0 {
+		if -shift >= len(pow10) || f.fp%pow10[-shift] != 0 {
+			return 0, ErrInexact
+		}
+		return f.fp / pow10[-shift], nil
+	}
+	if shift >= len(pow10) {
+		return 0, ErrTooLarge
+	}
+	hi, m := bits.Mul64(f.fp, pow10[shift])
+	if hi != 0 {
+		return 0, ErrTooLarge
+	}
+	return m, nil
+}
+
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
 func (f *Fixed) UnmarshalBinary(data []byte) error {
 	fp, n := binary.Uvarint(data)
 	if n < 0 {
-		return errFormat
+		return ErrFormat
 	}
 	f.fp = fp
 	return nil
@@ -423,23 +735,31 @@ func ReadFrom(r io.ByteReader) (Fixed, error) {
 	return Fixed{fp: fp}, nil
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a number, or a string holding a
+// number, "NaN" or "Inf"
 func (f *Fixed) UnmarshalJSON(bytes []byte) error {
 	s := string(bytes)
 	if s == "null" {
 		return nil
 	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
 
 	fixed, err := NewFromStringErr(s)
 	*f = fixed
 	if err != nil {
-		return fmt.Errorf("error decoding string '%s': %s", s, err)
+		return fmt.Errorf("error decoding string '%s': %w", s, err)
 	}
 	return nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. As JSON numbers cannot be NaN or infinite, NaN
+// and Inf are written as the strings "NaN" and "Inf"
 func (f Fixed) MarshalJSON() ([]byte, error) {
+	if f.IsNaN() || f.IsInf() {
+		return []byte(`"` + f.String() + `"`), nil
+	}
 	buffer := make([]byte, 24)
 	return itoa(buffer, f.fp), nil
 }
@@ -188,3 +188,64 @@ func BenchmarkWriteTo(b *testing.B) {
 		_ = f0.WriteTo(buf)
 	}
 }
+
+func benchVectors() (prices, quantities, values []Fixed) {
+	prices = make([]Fixed, 1024)
+	quantities = make([]Fixed, 1024)
+	values = make([]Fixed, 1024)
+	for i := range prices {
+		prices[i] = NewFromUintWithExponent(uint64(1234567+i), 4)
+		quantities[i] = NewFromUintWithExponent(uint64(250+i), 2)
+	}
+	return prices, quantities, values
+}
+
+func BenchmarkMulVec(b *testing.B) {
+	prices, quantities, values := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		_ = MulVec(values, prices, quantities)
+	}
+}
+func BenchmarkMulLoop(b *testing.B) {
+	prices, quantities, values := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		for j := range values {
+			values[j] = prices[j].Mul(quantities[j])
+		}
+	}
+}
+func BenchmarkAddVec(b *testing.B) {
+	prices, quantities, values := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		_ = AddVec(values, prices, quantities)
+	}
+}
+func BenchmarkAddLoop(b *testing.B) {
+	prices, quantities, values := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		for j := range values {
+			values[j] = prices[j].Add(quantities[j])
+		}
+	}
+}
+func BenchmarkDotProduct(b *testing.B) {
+	prices, quantities, _ := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = DotProduct(prices, quantities)
+	}
+}
+func BenchmarkDotProductLoop(b *testing.B) {
+	prices, quantities, _ := benchVectors()
+
+	for i := 0; i < b.N; i++ {
+		sum := ZERO
+		for j := range prices {
+			sum = sum.Add(prices[j].Mul(quantities[j]))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package fixed_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	. "github.com/cryptowrold/fixed"
 	"math"
 	"testing"
@@ -62,6 +63,67 @@ func TestNewI(t *testing.T) {
 
 }
 
+func TestMantissa(t *testing.T) {
+	tests := []struct {
+		m    int64
+		exp  int32
+		want string
+	}{
+		{123, -1, "12.3"},
+		{5, 3, "5000"},
+		{12345678, -8, "0.12345678"},
+		{1234567800, -10, "0.12345678"},
+		{99999999999, 0, "99999999999"},
+		{0, 100, "0"},
+	}
+	for _, test := range tests {
+		f, err := NewFromMantissa(test.m, test.exp)
+		if err != nil {
+			t.Error(test.m, test.exp, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", f, test.want)
+		}
+	}
+
+	if _, err := NewFromMantissa(123456789, -9); err == nil {
+		t.Error("should lose digits")
+	}
+	if _, err := NewFromMantissa(1, 11); err == nil {
+		t.Error("should be too large")
+	}
+	if _, err := NewFromUintMantissa(1<<63, 1); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := NewFromMantissa(-1, 0); err == nil {
+		t.Error("should be negative")
+	}
+
+	f := NewFromString("12.3")
+	m, err := f.Mantissa(-2)
+	if err != nil || m != 1230 {
+		t.Error("should be equal", m, 1230, err)
+	}
+	m, err = NewFromString("5000").Mantissa(3)
+	if err != nil || m != 5 {
+		t.Error("should be equal", m, 5, err)
+	}
+	if _, err := f.Mantissa(0); err == nil {
+		t.Error("should lose digits")
+	}
+	if _, err := MAX.Mantissa(-8); err == nil {
+		t.Error("should not fit an int64")
+	}
+	um, err := MAX.UintMantissa(-8)
+	if err != nil || um != MAX.Original() {
+		t.Error("should be equal", um, MAX.Original(), err)
+	}
+	if _, err := NaN.Mantissa(0); err == nil {
+		t.Error("NaN should fail")
+	}
+}
+
 func TestSign(t *testing.T) {
 	f0 := NewFromString("0")
 	if f0.Sign() != 0 {
@@ -311,6 +373,111 @@ func TestMulDiv(t *testing.T) {
 // 	}
 // }
 
+func TestMulDivFused(t *testing.T) {
+	// pro-rata: 1000 * 1/3, rounded once
+	f, err := NewFromString("1000").MulDiv(ONE, THREE, RoundDown)
+	if err != nil || f.String() != "333.33333333" {
+		t.Error("should be equal", f, "333.33333333", err)
+	}
+	f, _ = NewFromString("1000").MulDiv(TWO, THREE, RoundHalfUp)
+	if f.String() != "666.66666667" {
+		t.Error("should be equal", f, "666.66666667")
+	}
+	f, _ = NewFromString("0.00000001").MulDiv(NewFromString("0.5"), ONE, RoundHalfEven)
+	if f.String() != "0" {
+		t.Error("should be equal", f, "0")
+	}
+	f, _ = NewFromString("0.00000003").MulDiv(NewFromString("0.5"), ONE, RoundHalfEven)
+	if f.String() != "0.00000002" {
+		t.Error("should be equal", f, "0.00000002")
+	}
+
+	// the intermediate product exceeds MAX but the result fits
+	f0 := NewFromString("9000000000")
+	f, err = f0.MulDiv(f0, f0, RoundDown)
+	if err != nil || !f.Equal(f0) {
+		t.Error("should be equal", f, f0, err)
+	}
+	f, err = NewFromString("0.12345678").MulDiv(NewFromString("0.87654321"), NewFromString("0.5"), RoundDown)
+	if err != nil || f.String() != "0.2164304" {
+		t.Error("should be equal", f, "0.2164304", err)
+	}
+
+	if _, err := f0.MulDiv(f0, ONE, RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	// the quotient before rounding is the largest uint64, so rounding up must not wrap to zero
+	f0, f1 := NewFromOriginal(9999999999999999974), NewFromOriginal(9961241799803157898)
+	f2 := NewFromOriginal(5400000000000000000)
+	if f, err := f0.MulDiv(f1, f2, RoundUp); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", f, err)
+	}
+	if f, err := MAX.MulDiv(THREE, THREE, RoundUp); err != nil || !f.Equal(MAX) {
+		t.Error("should be equal", f, MAX, err)
+	}
+	if _, err := ONE.MulDiv(ONE, ZERO, RoundDown); err == nil {
+		t.Error("should divide by zero")
+	}
+	if f, _ := ONE.MulDiv(NaN, ONE, RoundDown); !f.IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
+func TestQuoRem(t *testing.T) {
+	f0 := NewFromString("1000.5")
+	f1 := NewFromString("0.3")
+
+	q, r, err := f0.DivMod(f1)
+	if err != nil {
+		t.Error(err)
+	}
+	if q.String() != "3335" || r.String() != "0" {
+		t.Error("should be equal", q, r, "3335", "0")
+	}
+
+	f0 = NewFromString("10.75")
+	f1 = NewFromString("2.5")
+	q, _ = f0.Quo(f1)
+	if q.String() != "4" {
+		t.Error("should be equal", q, "4")
+	}
+	r, _ = f0.Rem(f1)
+	if r.String() != "0.75" {
+		t.Error("should be equal", r, "0.75")
+	}
+
+	q, r, err = NewFromString("1").QuoRem(NewFromString("3"), 8)
+	if err != nil || q.String() != "0.33333333" || r.String() != "0.00000001" {
+		t.Error("should be equal", q, r, "0.33333333", "0.00000001", err)
+	}
+	q, r, err = NewFromString("10").QuoRem(NewFromString("3"), 2)
+	if err != nil || q.String() != "3.33" || r.String() != "0.01" {
+		t.Error("should be equal", q, r, "3.33", "0.01", err)
+	}
+	if !q.Mul(NewFromString("3")).Add(r).Equal(NewFromString("10")) {
+		t.Error("q*f0 + r should equal f", q, r)
+	}
+	if _, _, err := ONE.QuoRem(NewFromString("0.00000003"), 8); err == nil {
+		t.Error("remainder should have more than 8 places")
+	}
+
+	if _, err := ONE.Quo(ZERO); err == nil {
+		t.Error("should divide by zero")
+	}
+	if _, err := ONE.Rem(ZERO); err == nil {
+		t.Error("should divide by zero")
+	}
+	if _, _, err := ONE.QuoRem(ZERO, 2); err == nil {
+		t.Error("should divide by zero")
+	}
+	if _, err := NewFromString("1000").Quo(NewFromString("0.00000001")); err == nil {
+		t.Error("should overflow")
+	}
+	if q, _ := NaN.Quo(ONE); !q.IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
 func TestOverflow(t *testing.T) {
 	f0 := NewFromFloat(1.1234567)
 	if f0.String() != "1.1234567" {
@@ -337,7 +504,7 @@ func TestOverflow(t *testing.T) {
 	// f0 = NewFromString("99999999999.99")
 	// 184467440737 09551615
 	// 00000000 0000 00000000
-	f0 = NewFromUint(1<<64-1).Sub(NINE)
+	f0 = MAX.Sub(NINE)
 	f0 = f0.Add(NINE)
 	t.Log(f0)
 	assert.True(t, assert.Panics(t, func() {
@@ -359,6 +526,69 @@ func TestOverflow(t *testing.T) {
 	}))
 }
 
+func TestErrors(t *testing.T) {
+	var err error
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		_ = NewFromString("1.5").Sub(TWO)
+	}()
+	var ae *ArithmeticError
+	if !errors.As(err, &ae) || ae.Op != "Sub" || len(ae.Operands) != 2 || !errors.Is(err, ErrOverflow) {
+		t.Fatal("should be an overflow", err)
+	}
+	if err.Error() != "fixed: Sub(1.5, 2): integer overflow" {
+		t.Error("should be equal", err.Error())
+	}
+
+	// panics raised through float conversion and parsing name the public operation too
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		_ = MAX.Div(NewFromString("0.5"))
+	}()
+	if !errors.As(err, &ae) || ae.Op != "Div" || len(ae.Operands) != 2 || !errors.Is(err, ErrOverflow) {
+		t.Error("should be an overflow", err)
+	}
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		_ = NewFromFloat(-1)
+	}()
+	if !errors.As(err, &ae) || err.Error() != "fixed: NewFromFloat: -1: negative number" {
+		t.Error("should be negative", err)
+	}
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		_ = NewFromString("1x")
+	}()
+	if !errors.As(err, &ae) || ae.Op != "NewFromString" || !errors.Is(err, ErrFormat) {
+		t.Error("should be a format error", err)
+	}
+
+	_, err = ONE.MulDiv(TWO, ZERO, RoundDown)
+	if !errors.As(err, &ae) || ae.Op != "MulDiv" || !errors.Is(err, ErrDivisionByZero) {
+		t.Error("should be division by zero", err)
+	}
+	_, err = TEN.Quo(ZERO)
+	if err == nil || err.Error() != "fixed: Quo(10, 0): division by zero" {
+		t.Error("should be equal", err)
+	}
+	_, err = NewFromString("0.5").Ln(RoundDown)
+	if !errors.Is(err, ErrNegative) || err.Error() != "fixed: Ln(0.5): negative number" {
+		t.Error("should be negative", err)
+	}
+	_, err = NewFromStringErr("1x")
+	if !errors.Is(err, ErrFormat) {
+		t.Error("should be a format error", err)
+	}
+	_, err = NewFromStringErr("-1")
+	if !errors.Is(err, ErrNegative) {
+		t.Error("should be negative", err)
+	}
+	var f Fixed
+	if err := f.UnmarshalJSON([]byte("999999999999")); !errors.Is(err, ErrTooLarge) {
+		t.Error("should be too large", err)
+	}
+}
+
 func TestNaN(t *testing.T) {
 	f0 := NewFromFloat(math.NaN())
 	if !f0.IsNaN() {
@@ -372,6 +602,10 @@ func TestNaN(t *testing.T) {
 		t.Error("f0 should be NaN")
 	}
 
+	if !ONE.Sub(NaN).IsNaN() {
+		t.Error("should be NaN")
+	}
+
 	f0 = NewFromString("0.0004096")
 	if f0.String() != "0.0004096" {
 		t.Error("should be equal", f0.String(), "0.0004096")
@@ -379,6 +613,100 @@ func TestNaN(t *testing.T) {
 
 }
 
+func TestInf(t *testing.T) {
+	f0 := NewFromFloat(math.Inf(1))
+	if !f0.IsInf() || f0.IsNaN() {
+		t.Error("f0 should be Inf")
+	}
+	if f0.String() != "Inf" || NewFromString("Inf") != Inf {
+		t.Error("should be equal", f0.String(), "Inf")
+	}
+	if !math.IsInf(Inf.Float(), 1) {
+		t.Error("should be +Inf", Inf.Float())
+	}
+
+	tests := []struct {
+		f    Fixed
+		want string
+	}{
+		{Inf.Add(ONE), "Inf"},
+		{ONE.Add(Inf), "Inf"},
+		{Inf.Add(NaN), "NaN"},
+		{Inf.Sub(MAX), "Inf"},
+		{Inf.Sub(Inf), "NaN"},
+		{Inf.Mul(TWO), "Inf"},
+		{Inf.Mul(ZERO), "NaN"},
+		{Inf.Div(TWO), "Inf"},
+		{TWO.Div(Inf), "0"},
+		{Inf.Div(Inf), "NaN"},
+		{TWO.Div(ZERO), "Inf"},
+		{ZERO.Div(ZERO), "NaN"},
+	}
+	for i, test := range tests {
+		if test.f.String() != test.want {
+			t.Error("should be equal", i, test.f, test.want)
+		}
+	}
+	assert.Panics(t, func() { ONE.Sub(Inf) })
+	// an addition that would land on the Inf encoding is an overflow, not Inf
+	assert.Panics(t, func() { MAX.Add(NewFromOriginal(Inf.Original() - MAX.Original())) })
+
+	if Inf.Cmp(MAX) != 1 || Inf.Cmp(NaN) != -1 || Inf.Cmp(Inf) != 0 || !Inf.Equal(Inf) {
+		t.Error("Inf should order between MAX and NaN")
+	}
+
+	data, _ := json.Marshal([]Fixed{ONE, Inf, NaN})
+	if string(data) != `[1.00000000,"Inf","NaN"]` {
+		t.Error("should be equal", string(data), `[1.00000000,"Inf","NaN"]`)
+	}
+	var values []Fixed
+	if err := json.Unmarshal(data, &values); err != nil || !values[1].IsInf() || !values[2].IsNaN() {
+		t.Error("should round trip", values, err)
+	}
+}
+
+func TestNaNReason(t *testing.T) {
+	f, _ := NewFromStringErr("1x")
+	tests := []struct {
+		f    Fixed
+		want NaNReason
+	}{
+		{NaN, NaNExplicit},
+		{NewFromString("NaN"), NaNExplicit},
+		{f, NaNParse},
+		{NewNaN(NaNMissing), NaNMissing},
+		{Inf.Sub(Inf), NaNInvalid},
+		{ZERO.Div(ZERO), NaNDivisionByZero},
+		{ONE.Add(ZERO.Div(ZERO)), NaNDivisionByZero},
+		{NewNaN(NaNReason(100)), NaNExplicit},
+	}
+	for _, test := range tests {
+		reason, ok := test.f.NaNReason()
+		if !ok || reason != test.want {
+			t.Error("should be equal", reason, test.want)
+		}
+		if !test.f.IsNaN() || test.f.IsInf() || test.f.String() != "NaN" {
+			t.Error("should be NaN", test.f)
+		}
+		if test.f.Equal(NaN) || test.f.Cmp(NaN) != 0 || test.f.Cmp(Inf) != 1 {
+			t.Error("should compare as NaN", test.want)
+		}
+	}
+	if _, ok := ONE.NaNReason(); ok {
+		t.Error("should not be NaN")
+	}
+	if _, ok := Inf.NaNReason(); ok {
+		t.Error("Inf should not be NaN")
+	}
+
+	data, _ := f.MarshalBinary()
+	var f0 Fixed
+	_ = f0.UnmarshalBinary(data)
+	if reason, _ := f0.NaNReason(); reason != NaNParse {
+		t.Error("binary should keep the reason", reason)
+	}
+}
+
 func TestIntFrac(t *testing.T) {
 	f0 := NewFromFloat(1234.5678)
 	if f0.UInt() != 1234 {
@@ -0,0 +1,365 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Sqrt returns the square root of f, truncated to 8 decimal places. It is computed by integer Newton
+// iteration and is exact in the 8th place on every platform. NaN and Inf are returned unchanged
+func (f Fixed) Sqrt() Fixed {
+	if f.IsNaN() || f.IsInf() {
+		return f
+	}
+	// sqrt(fp / 10^8) * 10^8 == sqrt(fp * 10^8)
+	hi, lo := bits.Mul64(f.fp, scale)
+	return Fixed{fp: uint128{hi: hi, lo: lo}.sqrt()}
+}
+
+// PowInt returns f raised to the integer power n, which may be negative, rounded once according to
+// mode. It uses exponentiation by squaring on integers carrying enough significant digits to round
+// correctly, so results are exact and deterministic across platforms. An error is returned on overflow,
+// or if f is zero and n is negative. If f is NaN, NaN is returned. Inf to a positive power is Inf and to
+// a negative power is zero
+func (f Fixed) PowInt(n int, mode RoundingMode) (Fixed, error) {
+	result, err := f.powInt(n, mode)
+	return result, arithmeticError("PowInt", err, f)
+}
+
+// powInt computes PowInt, returning a bare sentinel error
+func (f Fixed) powInt(n int, mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() {
+		return NaN, nil
+	}
+	if n == 0 {
+		return ONE, nil
+	}
+	if f.IsInf() {
+		if n < 0 {
+			return ZERO, nil
+		}
+		return Inf, nil
+	}
+	if f.fp == 0 {
+		if n < 0 {
+			return NaN, ErrDivisionByZero
+		}
+		return ZERO, nil
+	}
+
+	// -n wraps for the smallest int, but uint64 of it is still the magnitude
+	negative, e := n < 0, uint64(n)
+	if negative {
+		e = uint64(-n)
+	}
+	// f^e may be far from one, so rather than a fixed number of places it is carried with a fixed number
+	// of significant digits. It is computed twice, rounding each intermediate down and then up, so the
+	// exact value lies between the two, and the digits are doubled until both give the same result once
+	// rounded according to mode
+	for digits := 40; ; digits *= 2 {
+		lo, errLo := powBound(f.fp, e, negative, digits, false, mode)
+		hi, errHi := powBound(f.fp, e, negative, digits, true, mode)
+		if lo == hi && errLo == errHi {
+			return lo, errLo
+		}
+	}
+}
+
+// powBound returns (fp/10^8)^e, or its reciprocal if negative is set, rounded according to mode, with
+// the power computed to digits significant digits, rounding each intermediate up if up is set and down
+// otherwise
+func powBound(fp, e uint64, negative bool, digits int, up bool, mode RoundingMode) (Fixed, error) {
+	// the power so far is vm * 10^vx and the current square of the base bm * 10^bx
+	vm, vx := big.NewInt(1), 0
+	bm, bx := new(big.Int).SetUint64(fp), -nPlaces
+	for {
+		if e&1 == 1 {
+			vm, vx = mulDigits(vm, vx, bm, bx, digits, up)
+			if result, done, err := powBeyond(vm, vx, negative, mode); done {
+				return result, err
+			}
+		}
+		e >>= 1
+		if e == 0 {
+			break
+		}
+		bm, bx = mulDigits(bm, bx, bm, bx, digits, up)
+		// every remaining factor lies on the same side of one as the square, so the power ends up
+		// beyond it too
+		if result, done, err := powBeyond(bm, bx, negative, mode); done {
+			return result, err
+		}
+	}
+	if negative {
+		// f^-e in original units is 10^8 / (vm * 10^vx)
+		if vx > 0 {
+			return powResult(fromBigQuo(bigPow10(nPlaces), vm.Mul(vm, bigPow10(vx)), mode))
+		}
+		return powResult(fromBigQuo(bigPow10(nPlaces-vx), vm, mode))
+	}
+	// f^e in original units is vm * 10^(vx+8)
+	if vx+nPlaces >= 0 {
+		return powResult(fromBigInt(vm.Mul(vm, bigPow10(vx+nPlaces))))
+	}
+	return powResult(fromBigQuo(vm, bigPow10(-vx-nPlaces), mode))
+}
+
+// powBeyond reports whether m * 10^x, or its reciprocal if negative is set, is known without further
+// work: too large for a Fixed, or below a tenth of the last place
+func powBeyond(m *big.Int, x int, negative bool, mode RoundingMode) (Fixed, bool, error) {
+	// m * 10^x lies in [10^(magnitude-1), 10^magnitude)
+	magnitude := len(m.String()) + x
+	if negative {
+		// the reciprocal lies in (10^-magnitude, 10^(1-magnitude)]
+		magnitude = 1 - magnitude
+	}
+	switch {
+	// at least 10^11, above MAX
+	case magnitude > 11:
+		return NaN, true, ErrOverflow
+	// at most 10^-9
+	case magnitude <= -nPlaces-1:
+		if mode == RoundUp {
+			return Fixed{fp: 1}, true, nil
+		}
+		return ZERO, true, nil
+	}
+	return NaN, false, nil
+}
+
+// mulDigits returns the product of am * 10^ax and bm * 10^bx as m * 10^x with at most digits significant
+// digits, rounded up if up is set and down otherwise
+func mulDigits(am *big.Int, ax int, bm *big.Int, bx int, digits int, up bool) (*big.Int, int) {
+	m := new(big.Int).Mul(am, bm)
+	x := ax + bx
+	if n := len(m.String()); n > digits {
+		var r big.Int
+		m.QuoRem(m, bigPow10(n-digits), &r)
+		if up && r.Sign() != 0 {
+			m.Add(m, big.NewInt(1))
+		}
+		x += n - digits
+	}
+	return m, x
+}
+
+// powResult reports a result too large for a Fixed as an overflow
+func powResult(f Fixed, err error) (Fixed, error) {
+	if err != nil {
+		return NaN, ErrOverflow
+	}
+	return f, nil
+}
+
+// working places of the transcendental functions below. With 40 guard digits the error of an
+// intermediate is far below 10^-20 of the last place, even after Pow scales the logarithm by a
+// large exponent
+const mathPlaces = 48
+
+var (
+	mathOne  = bigPow10(mathPlaces)
+	mathLn2  = atanhSeries(new(big.Int).Quo(mathOne, big.NewInt(3)))
+	mathLn10 = new(big.Int).Add(new(big.Int).Mul(mathLn2, big.NewInt(3)), atanhSeries(new(big.Int).Quo(mathOne, big.NewInt(9))))
+	// exp of anything above this exceeds MAX, and exp of anything below mathTiny is under 10^-9
+	mathHuge = new(big.Int).Mul(mathOne, big.NewInt(26))
+	mathTiny = new(big.Int).Mul(mathOne, big.NewInt(-21))
+	// results within 10^-20 of a multiple of 10^-8 are taken to be that multiple
+	mathSnap = bigPow10(mathPlaces - 20)
+)
+
+// Exp returns e raised to the power f, rounded according to mode. It is computed in integer arithmetic
+// and is deterministic across platforms. Intermediates carry 40 guard digits, so the result is the
+// exact value rounded according to mode, except that a value within 10^-20 of a multiple of 10^-8 is
+// taken to be that multiple, and a value within 10^-20 of a halfway point may round either way.
+// An error is returned on overflow. If f is NaN, NaN is returned, and Exp of Inf is Inf
+func (f Fixed) Exp(mode RoundingMode) (Fixed, error) {
+	result, err := f.exp(mode)
+	return result, arithmeticError("Exp", err, f)
+}
+
+// exp computes Exp, returning a bare sentinel error
+func (f Fixed) exp(mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() || f.IsInf() {
+		return f, nil
+	}
+	return expFixed(toMath(f), mode)
+}
+
+// Ln returns the natural logarithm of f, rounded as for Exp. As a Fixed cannot be negative, an error
+// is returned if f is less than one. If f is NaN, NaN is returned, and Ln of Inf is Inf
+func (f Fixed) Ln(mode RoundingMode) (Fixed, error) {
+	result, err := f.ln(mode)
+	return result, arithmeticError("Ln", err, f)
+}
+
+// ln computes Ln, returning a bare sentinel error
+func (f Fixed) ln(mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() || f.IsInf() {
+		return f, nil
+	}
+	if f.fp == 0 {
+		return NaN, ErrDivisionByZero
+	}
+	if f.fp < ONE.fp {
+		return NaN, ErrNegative
+	}
+	return fromMath(lnMath(toMath(f)), mode)
+}
+
+// Log10 returns the base 10 logarithm of f, rounded as for Exp. As a Fixed cannot be negative, an
+// error is returned if f is less than one. If f is NaN, NaN is returned, and Log10 of Inf is Inf
+func (f Fixed) Log10(mode RoundingMode) (Fixed, error) {
+	result, err := f.log10(mode)
+	return result, arithmeticError("Log10", err, f)
+}
+
+// log10 computes Log10, returning a bare sentinel error
+func (f Fixed) log10(mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() || f.IsInf() {
+		return f, nil
+	}
+	if f.fp == 0 {
+		return NaN, ErrDivisionByZero
+	}
+	if f.fp < ONE.fp {
+		return NaN, ErrNegative
+	}
+	ln := lnMath(toMath(f))
+	return fromMath(ln.Quo(ln.Mul(ln, mathOne), mathLn10), mode)
+}
+
+// Pow returns f raised to the power y, computed as e^(y*ln(f)) and rounded as for Exp. Use PowInt
+// for integer powers. An error is returned on overflow. If either operand is NaN, NaN is returned. Inf
+// to a non-zero power is Inf, and a power of Inf is zero, one or Inf as f is below, at or above one
+func (f Fixed) Pow(y Fixed, mode RoundingMode) (Fixed, error) {
+	result, err := f.pow(y, mode)
+	return result, arithmeticError("Pow", err, f, y)
+}
+
+// pow computes Pow, returning a bare sentinel error
+func (f Fixed) pow(y Fixed, mode RoundingMode) (Fixed, error) {
+	if f.IsNaN() || y.IsNaN() {
+		return NaN, nil
+	}
+	if y.fp == 0 {
+		return ONE, nil
+	}
+	if f.fp == 0 {
+		return ZERO, nil
+	}
+	if f.IsInf() {
+		return Inf, nil
+	}
+	if y.IsInf() {
+		switch f.Cmp(ONE) {
+		case -1:
+			return ZERO, nil
+		case 0:
+			return ONE, nil
+		}
+		return Inf, nil
+	}
+	t := lnMath(toMath(f))
+	t.Mul(t, toMath(y))
+	return expFixed(t.Quo(t, mathOne), mode)
+}
+
+// expFixed returns e^t for t at the working scale, rounded to a Fixed
+func expFixed(t *big.Int, mode RoundingMode) (Fixed, error) {
+	if t.Cmp(mathHuge) > 0 {
+		return NaN, ErrOverflow
+	}
+	if t.Cmp(mathTiny) < 0 {
+		if mode == RoundUp {
+			return Fixed{fp: 1}, nil
+		}
+		return ZERO, nil
+	}
+	f, err := fromMath(expMath(t), mode)
+	if err != nil {
+		return NaN, ErrOverflow
+	}
+	return f, nil
+}
+
+// toMath converts f to the working scale
+func toMath(f Fixed) *big.Int {
+	x := new(big.Int).SetUint64(f.fp)
+	return x.Mul(x, bigPow10(mathPlaces-nPlaces))
+}
+
+// fromMath rounds a non-negative value at the working scale to a Fixed, snapping values within
+// mathSnap of a multiple of 10^-8 to that multiple
+func fromMath(x *big.Int, mode RoundingMode) (Fixed, error) {
+	d := bigPow10(mathPlaces - nPlaces)
+	q, r := new(big.Int).QuoRem(x, d, new(big.Int))
+	if r.Cmp(mathSnap) <= 0 {
+		return fromBigInt(q)
+	}
+	if r.Add(r, mathSnap).Cmp(d) >= 0 {
+		return fromBigInt(q.Add(q, big.NewInt(1)))
+	}
+	if mode.roundUpBig(q, r, d) {
+		q.Add(q, big.NewInt(1))
+	}
+	return fromBigInt(q)
+}
+
+// lnMath returns the natural logarithm of x > 0 at the working scale. x is reduced to m * 2^k with
+// m in [1, 2), and ln(m) is found from the series for 2*atanh((m-1)/(m+1))
+func lnMath(x *big.Int) *big.Int {
+	k := x.BitLen() - mathOne.BitLen()
+	m := new(big.Int)
+	if k >= 0 {
+		m.Rsh(x, uint(k))
+	} else {
+		m.Lsh(x, uint(-k))
+	}
+	two := new(big.Int).Lsh(mathOne, 1)
+	for m.Cmp(two) >= 0 {
+		m.Rsh(m, 1)
+		k++
+	}
+	for m.Cmp(mathOne) < 0 {
+		m.Lsh(m, 1)
+		k--
+	}
+	z := new(big.Int).Sub(m, mathOne)
+	z.Quo(z.Mul(z, mathOne), m.Add(m, mathOne))
+	ln := atanhSeries(z)
+	return ln.Add(ln, new(big.Int).Mul(mathLn2, big.NewInt(int64(k))))
+}
+
+// atanhSeries returns 2*atanh(z) = 2 * (z + z^3/3 + z^5/5 + ...) for 0 <= z < 1 at the working scale
+func atanhSeries(z *big.Int) *big.Int {
+	z2 := new(big.Int).Mul(z, z)
+	z2.Quo(z2, mathOne)
+	sum := new(big.Int)
+	term := new(big.Int).Set(z)
+	t := new(big.Int)
+	for i := int64(1); term.Sign() != 0; i += 2 {
+		sum.Add(sum, t.Quo(term, big.NewInt(i)))
+		term.Quo(term.Mul(term, z2), mathOne)
+	}
+	return sum.Lsh(sum, 1)
+}
+
+// expMath returns e^t at the working scale. t is reduced to r + k*ln(2) with |r| < ln(2), and e^r is
+// found from its Taylor series
+func expMath(t *big.Int) *big.Int {
+	k, r := new(big.Int).QuoRem(t, mathLn2, new(big.Int))
+	sum := new(big.Int).Set(mathOne)
+	term := new(big.Int).Set(mathOne)
+	for i := int64(1); term.Sign() != 0; i++ {
+		term.Mul(term, r)
+		term.Quo(term, mathOne)
+		term.Quo(term, big.NewInt(i))
+		sum.Add(sum, term)
+	}
+	if n := k.Int64(); n < 0 {
+		return sum.Rsh(sum, uint(-n))
+	}
+	return sum.Lsh(sum, uint(k.Int64()))
+}
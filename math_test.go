@@ -0,0 +1,269 @@
+package fixed_test
+
+import (
+	. "github.com/cryptowrold/fixed"
+	"github.com/shopspring/decimal"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"0", "0"},
+		{"1", "1"},
+		{"2", "1.41421356"},
+		{"4", "2"},
+		{"0.0001", "0.01"},
+		{"0.00000001", "0.0001"},
+		{"99999999999.99999999", "316227.76601683"},
+	}
+	for _, test := range tests {
+		f := NewFromString(test.s).Sqrt()
+		if f.String() != test.want {
+			t.Error("should be equal", test.s, f, test.want)
+		}
+	}
+	if !NaN.Sqrt().IsNaN() {
+		t.Error("should be NaN")
+	}
+
+	// r*r <= f < (r+1ulp)*(r+1ulp) in original units
+	for _, s := range []string{"3", "12345.6789", "0.5", "7777777777.77777777"} {
+		f := NewFromString(s)
+		r := new(big.Int).SetUint64(f.Sqrt().Original())
+		x := new(big.Int).Mul(new(big.Int).SetUint64(f.Original()), big.NewInt(1e8))
+		r2 := new(big.Int).Mul(r, r)
+		r.Add(r, big.NewInt(1))
+		if r2.Cmp(x) > 0 || r.Mul(r, r).Cmp(x) <= 0 {
+			t.Error("not the truncated root", s, f.Sqrt())
+		}
+	}
+}
+
+// powRef returns f^n rounded according to mode, computed exactly with math/big
+func powRef(f Fixed, n int, mode RoundingMode) Fixed {
+	if n < 0 {
+		// f^n == 10^(8*-n) / fp^-n
+		den := new(big.Int).Exp(new(big.Int).SetUint64(f.Original()), big.NewInt(int64(-n)), nil)
+		num := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-8*n)), nil)
+		f0, _ := NewFromBigRat(new(big.Rat).SetFrac(num, den), mode)
+		return f0
+	}
+	num := new(big.Int).Exp(new(big.Int).SetUint64(f.Original()), big.NewInt(int64(n)), nil)
+	f0, _ := NewFromDecimal(decimal.NewFromBigInt(num, int32(-8*n)), mode)
+	return f0
+}
+
+func TestPowInt(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		mode RoundingMode
+		want string
+	}{
+		{"1.1", 2, RoundDown, "1.21"},
+		{"2", 10, RoundDown, "1024"},
+		{"2", -1, RoundDown, "0.5"},
+		{"3", -1, RoundHalfUp, "0.33333333"},
+		{"3", -1, RoundUp, "0.33333334"},
+		{"1.5", 0, RoundDown, "1"},
+		{"0", 5, RoundDown, "0"},
+		{"2", -40, RoundDown, "0"},
+		{"2", -40, RoundUp, "0.00000001"},
+		{"0.1", 8, RoundDown, "0.00000001"},
+		{"0.1", 9, RoundHalfUp, "0"},
+		{"0.07376974", 26, RoundUp, "0.00000001"},
+		{"10", 10, RoundDown, "10000000000"},
+	}
+	for _, test := range tests {
+		f, err := NewFromString(test.s).PowInt(test.n, test.mode)
+		if err != nil {
+			t.Error(test.s, test.n, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", test.s, test.n, f, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		s string
+		n int
+	}{
+		{"1.0001", 10000},
+		{"1.00000001", 123456},
+		{"0.99999", 777},
+		{"1.05", 30},
+		{"1.05", -30},
+		{"123.456", 3},
+		{"0.5", -20},
+		// results near 10^10 need 19 significant digits of the reciprocal
+		{"0.01663251", -6},
+		{"0.01736128", -6},
+		{"0.0001", -2},
+		{"0.3", -21},
+		{"0.99999999", -2000},
+		{"1.00000001", -2000},
+		// tiny results must not collapse to zero before rounding up
+		{"0.07376974", 26},
+		{"0.30796604", 185},
+		{"0.20024559", 881},
+		{"0.85924374", 1044},
+		{"0.1", 9},
+	} {
+		for _, mode := range []RoundingMode{RoundDown, RoundUp, RoundHalfUp, RoundHalfEven} {
+			f, err := NewFromString(test.s).PowInt(test.n, mode)
+			if err != nil {
+				t.Error(test.s, test.n, err)
+				continue
+			}
+			if want := powRef(NewFromString(test.s), test.n, mode); !f.Equal(want) {
+				t.Error("should be equal", test.s, test.n, mode, f, want)
+			}
+		}
+	}
+
+	if _, err := NewFromString("10").PowInt(11, RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := NewFromString("1.1").PowInt(1000000, RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := NewFromString("0.00000001").PowInt(-2, RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := ZERO.PowInt(-1, RoundDown); err == nil {
+		t.Error("should divide by zero")
+	}
+	if f, _ := NaN.PowInt(2, RoundDown); !f.IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
+const refPrec = 400
+
+// refExp returns e^x from its Taylor series in math/big
+func refExp(x *big.Float) *big.Float {
+	sum := new(big.Float).SetPrec(refPrec).SetInt64(1)
+	term := new(big.Float).SetPrec(refPrec).SetInt64(1)
+	eps := new(big.Float).SetMantExp(big.NewFloat(1), -refPrec)
+	for i := int64(1); new(big.Float).Abs(term).Cmp(eps) > 0; i++ {
+		term.Mul(term, x)
+		term.Quo(term, new(big.Float).SetInt64(i))
+		sum.Add(sum, term)
+	}
+	return sum
+}
+
+// refLn returns ln(x) by Newton iteration on refExp
+func refLn(x *big.Float) *big.Float {
+	f, _ := x.Float64()
+	y := new(big.Float).SetPrec(refPrec).SetFloat64(math.Log(f))
+	for i := 0; i < 6; i++ {
+		e := refExp(y)
+		num := new(big.Float).SetPrec(refPrec).Sub(x, e)
+		num.Mul(num, big.NewFloat(2))
+		y.Add(y, num.Quo(num, e.Add(e, x)))
+	}
+	return y
+}
+
+func refFixed(f Fixed) *big.Float {
+	x, _ := f.BigFloat(refPrec)
+	return x
+}
+
+func TestExpLn(t *testing.T) {
+	tests := []struct {
+		f    func() (Fixed, error)
+		want string
+	}{
+		{func() (Fixed, error) { return ONE.Exp(RoundHalfEven) }, "2.71828183"},
+		{func() (Fixed, error) { return ONE.Exp(RoundDown) }, "2.71828182"},
+		{func() (Fixed, error) { return ZERO.Exp(RoundDown) }, "1"},
+		{func() (Fixed, error) { return TEN.Exp(RoundHalfEven) }, "22026.46579481"},
+		{func() (Fixed, error) { return TWO.Ln(RoundHalfEven) }, "0.69314718"},
+		{func() (Fixed, error) { return TEN.Ln(RoundHalfEven) }, "2.30258509"},
+		{func() (Fixed, error) { return ONE.Ln(RoundUp) }, "0"},
+		{func() (Fixed, error) { return TWO.Log10(RoundHalfEven) }, "0.30103"},
+		{func() (Fixed, error) { return NewFromString("1000").Log10(RoundDown) }, "3"},
+		{func() (Fixed, error) { return NewFromString("1000").Log10(RoundUp) }, "3"},
+		{func() (Fixed, error) { return FOUR.Pow(NewFromString("0.5"), RoundDown) }, "2"},
+		{func() (Fixed, error) { return FOUR.Pow(NewFromString("0.5"), RoundUp) }, "2"},
+		{func() (Fixed, error) { return TWO.Pow(NewFromString("0.5"), RoundHalfEven) }, "1.41421356"},
+		{func() (Fixed, error) { return TWO.Pow(TEN, RoundDown) }, "1024"},
+		{func() (Fixed, error) { return NewFromString("1.0001").Pow(NewFromString("10000"), RoundHalfEven) }, "2.71814593"},
+		{func() (Fixed, error) { return NewFromString("0.5").Pow(NewFromString("100"), RoundHalfEven) }, "0"},
+		{func() (Fixed, error) { return NewFromString("0.5").Pow(NewFromString("100"), RoundUp) }, "0.00000001"},
+		{func() (Fixed, error) { return ZERO.Pow(TWO, RoundDown) }, "0"},
+	}
+	for i, test := range tests {
+		f, err := test.f()
+		if err != nil {
+			t.Error(i, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", i, f, test.want)
+		}
+	}
+
+	modes := []RoundingMode{RoundDown, RoundUp, RoundHalfEven}
+	for _, s := range []string{"0.00000001", "0.5", "1.23456789", "7", "12.5", "25.3"} {
+		x := NewFromString(s)
+		for _, mode := range modes {
+			f, err := x.Exp(mode)
+			want, _ := NewFromBigFloat(refExp(refFixed(x)), mode)
+			if err != nil || !f.Equal(want) {
+				t.Error("exp should be equal", s, mode, f, want, err)
+			}
+		}
+	}
+	for _, s := range []string{"1.00000001", "1.5", "3", "12345.6789", "99999999999.99999999"} {
+		x := NewFromString(s)
+		for _, mode := range modes {
+			f, err := x.Ln(mode)
+			want, _ := NewFromBigFloat(refLn(refFixed(x)), mode)
+			if err != nil || !f.Equal(want) {
+				t.Error("ln should be equal", s, mode, f, want, err)
+			}
+			f, err = x.Log10(mode)
+			ref := refLn(refFixed(x))
+			want, _ = NewFromBigFloat(ref.Quo(ref, refLn(refFixed(TEN))), mode)
+			if err != nil || !f.Equal(want) {
+				t.Error("log10 should be equal", s, mode, f, want, err)
+			}
+		}
+	}
+	for _, p := range [][2]string{{"0.5", "3.3"}, {"123.456", "0.789"}, {"9999", "2.5"}, {"1.00000001", "99999999"}, {"0.999", "12.34567891"}} {
+		x, y := NewFromString(p[0]), NewFromString(p[1])
+		for _, mode := range modes {
+			f, err := x.Pow(y, mode)
+			ref := refLn(refFixed(x))
+			want, _ := NewFromBigFloat(refExp(ref.Mul(ref, refFixed(y))), mode)
+			if err != nil || !f.Equal(want) {
+				t.Error("pow should be equal", p, mode, f, want, err)
+			}
+		}
+	}
+
+	if _, err := NewFromString("25.4").Exp(RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := TEN.Pow(NewFromString("11"), RoundDown); err == nil {
+		t.Error("should overflow")
+	}
+	if _, err := NewFromString("0.5").Ln(RoundDown); err == nil {
+		t.Error("should be negative")
+	}
+	if _, err := ZERO.Log10(RoundDown); err == nil {
+		t.Error("should fail")
+	}
+	if f, _ := NaN.Exp(RoundDown); !f.IsNaN() {
+		t.Error("should be NaN")
+	}
+}
@@ -0,0 +1,133 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// MsgpackExtType is the MessagePack extension type used to encode a Fixed. It may be changed
+// before any encoding takes place if it collides with another application defined type
+var MsgpackExtType int8 = 1
+
+// MessagePack format bytes
+const (
+	msgpackFixExt8 = 0xd7
+	msgpackFloat32 = 0xca
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackUint64  = 0xcf
+	msgpackInt8    = 0xd0
+	msgpackInt64   = 0xd3
+	msgpackNil     = 0xc0
+	msgpackStr8    = 0xd9
+	msgpackStr32   = 0xdb
+)
+
+// MarshalMsgpack implements the vmihailenco/msgpack Marshaler interface, encoding f as a fixext 8
+// extension of type MsgpackExtType holding the big endian fixed point value
+func (f Fixed) MarshalMsgpack() ([]byte, error) {
+	b := make([]byte, 2, 10)
+	b[0] = msgpackFixExt8
+	b[1] = byte(MsgpackExtType)
+	return binary.BigEndian.AppendUint64(b, f.fp), nil
+}
+
+// UnmarshalMsgpack implements the vmihailenco/msgpack Unmarshaler interface. It accepts the
+// extension written by MarshalMsgpack, a non-negative integer, a string or a floating point NaN or
+// positive infinity.
+// Like UnmarshalJSON, nil leaves f unchanged
+func (f *Fixed) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 0 {
+		return ErrFormat
+	}
+	c := data[0]
+	var fixed Fixed
+	var size int
+	var err error
+	switch {
+	case c == msgpackNil:
+		if len(data) != 1 {
+			return ErrFormat
+		}
+		return nil
+	case c == msgpackFixExt8:
+		if len(data) != 10 || int8(data[1]) != MsgpackExtType {
+			return ErrFormat
+		}
+		fixed = Fixed{fp: binary.BigEndian.Uint64(data[2:])}
+		size = 10
+	case c <= 0x7f:
+		fixed, err = NewFromUintMantissa(uint64(c), 0)
+		size = 1
+	case c >= 0xe0:
+		err = ErrNegative
+	case c >= msgpackUint8 && c <= msgpackUint64:
+		var v uint64
+		v, size, err = msgpackUint(data, 1<<(c-msgpackUint8))
+		if err == nil {
+			fixed, err = NewFromUintMantissa(v, 0)
+		}
+	case c >= msgpackInt8 && c <= msgpackInt64:
+		var v uint64
+		n := 1 << (c - msgpackInt8)
+		v, size, err = msgpackUint(data, n)
+		if err == nil && v>>(n*8-1) != 0 {
+			err = ErrNegative
+		}
+		if err == nil {
+			fixed, err = NewFromUintMantissa(v, 0)
+		}
+	case c >= 0xa0 && c <= 0xbf, c >= msgpackStr8 && c <= msgpackStr32:
+		var l uint64
+		var n int
+		if c <= 0xbf {
+			l, n = uint64(c&0x1f), 1
+		} else {
+			l, n, err = msgpackUint(data, 1<<(c-msgpackStr8))
+		}
+		if err == nil && l > uint64(len(data)-n) {
+			err = ErrFormat
+		}
+		if err == nil {
+			size = n + int(l)
+			fixed, err = NewFromStringErr(string(data[n:size]))
+		}
+	case c == msgpackFloat32 && len(data) >= 5:
+		fixed, err = msgpackFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(data[1:]))))
+		size = 5
+	case c == msgpackFloat64 && len(data) >= 9:
+		fixed, err = msgpackFloat(math.Float64frombits(binary.BigEndian.Uint64(data[1:])))
+		size = 9
+	default:
+		err = ErrFormat
+	}
+	if err != nil {
+		return err
+	}
+	if size != len(data) {
+		return ErrFormat
+	}
+	*f = fixed
+	return nil
+}
+
+// msgpackFloat accepts a floating point NaN or positive infinity
+func msgpackFloat(x float64) (Fixed, error) {
+	if !math.IsNaN(x) && !math.IsInf(x, 1) {
+		return NaN, ErrFormat
+	}
+	return NewFromFloat(x), nil
+}
+
+// msgpackUint reads the n byte big endian integer following the format byte, returning it
+// along with the total size of the header
+func msgpackUint(data []byte, n int) (uint64, int, error) {
+	if len(data) < 1+n {
+		return 0, 0, ErrFormat
+	}
+	var buf [8]byte
+	copy(buf[8-n:], data[1:1+n])
+	return binary.BigEndian.Uint64(buf[:]), 1 + n, nil
+}
@@ -0,0 +1,66 @@
+package fixed_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestMsgpack(t *testing.T) {
+	f := NewFromString("12345.12345")
+	data, err := f.MarshalMsgpack()
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(data, golden(t, "d7010000011f6eab77a8")) {
+		t.Error("should be equal", hex.EncodeToString(data), "d7010000011f6eab77a8")
+	}
+	var f0 Fixed
+	if err := f0.UnmarshalMsgpack(data); err != nil {
+		t.Error(err)
+	}
+	if !f.Equal(f0) {
+		t.Error("don't match", f, f0)
+	}
+
+	data, _ = NaN.MarshalMsgpack()
+	_ = f0.UnmarshalMsgpack(data)
+	if !f0.IsNaN() {
+		t.Error("f0 should be NaN")
+	}
+
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"07", "7"},                   // positive fixint
+		{"cd03e8", "1000"},            // uint16
+		{"d20000c350", "50000"},       // int32
+		{"a631322e333435", "12.345"},  // fixstr
+		{"d903302e31", "0.1"},         // str8
+		{"cb7ff8000000000000", "NaN"}, // float64 NaN
+		{"ca7f800000", "Inf"},         // float32 infinity
+	}
+	for _, test := range tests {
+		var f Fixed
+		if err := f.UnmarshalMsgpack(golden(t, test.data)); err != nil {
+			t.Error(test.data, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", f.String(), test.want)
+		}
+	}
+
+	f0 = ONE
+	if err := f0.UnmarshalMsgpack(golden(t, "c0")); err != nil || !f0.Equal(ONE) {
+		t.Error("nil should leave the value unchanged", f0, err)
+	}
+
+	for _, s := range []string{"", "ff", "d0ff", "d7020000011f6eab77a8", "cb3ff0000000000000", "a5313233", "0707", "90"} {
+		if err := f0.UnmarshalMsgpack(golden(t, s)); err == nil {
+			t.Error("should fail", s)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package fixed
+
+// release under the terms of file license.txt
+
+// NaNReason records why a NaN was produced. Every NaN is treated alike by IsNaN, Cmp and Equal
+// whatever its reason; the reason only helps to diagnose where a NaN in a report came from
+type NaNReason uint8
+
+const (
+	// NaNExplicit is the reason of the NaN value itself, and of a NaN with no more specific reason
+	NaNExplicit NaNReason = iota
+	// NaNParse is the reason of the NaN returned by a failed NewFromStringErr
+	NaNParse
+	// NaNMissing marks a value that was absent, such as a null in an Arrow or Parquet column
+	NaNMissing
+	// NaNInvalid is the reason of an operation with no defined result, such as Inf-Inf or Inf*0
+	NaNInvalid
+	// NaNDivisionByZero is the reason of zero divided by zero
+	NaNDivisionByZero
+
+	maxNaNReason = NaNDivisionByZero
+)
+
+func (reason NaNReason) String() string {
+	switch reason {
+	case NaNExplicit:
+		return "NaNExplicit"
+	case NaNParse:
+		return "NaNParse"
+	case NaNMissing:
+		return "NaNMissing"
+	case NaNInvalid:
+		return "NaNInvalid"
+	case NaNDivisionByZero:
+		return "NaNDivisionByZero"
+	}
+	return "NaNReason(?)"
+}
+
+// NewNaN returns a NaN carrying reason. An unknown reason gives NaN. Binary, protobuf and MessagePack
+// encodings preserve the reason; text encodings write every NaN as "NaN"
+func NewNaN(reason NaNReason) Fixed {
+	if reason == NaNExplicit || reason > maxNaNReason {
+		return NaN
+	}
+	return Fixed{fp: inf - uint64(reason)}
+}
+
+// NaNReason returns the reason f is NaN, or false if f is not NaN
+func (f Fixed) NaNReason() (NaNReason, bool) {
+	if !f.IsNaN() {
+		return 0, false
+	}
+	if f.fp == nan {
+		return NaNExplicit, true
+	}
+	return NaNReason(inf - f.fp), true
+}
@@ -0,0 +1,68 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelMinShard is the fewest values worth handing to a goroutine of their own
+const parallelMinShard = 1 << 16
+
+// ParallelAccumulate adds values to an Accumulator using up to workers goroutines, or GOMAXPROCS if
+// workers is not positive. values is split into contiguous shards, each summed into its own Accumulator,
+// and the shards are merged in order. As the sums are exact integers and a NaN is taken from the first
+// shard holding one, the result is bit-identical to a sequential Accumulator whatever the number of
+// workers. NaN values are skipped or propagated according to policy
+func ParallelAccumulate(values []Fixed, policy NaNPolicy, workers int) Accumulator {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = minInt(workers, (len(values)+parallelMinShard-1)/parallelMinShard)
+	if workers <= 1 {
+		var a Accumulator
+		accumulateShard(&a, values, policy)
+		return a
+	}
+
+	shards := make([]Accumulator, workers)
+	size := (len(values) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for i := range shards {
+		start, end := i*size, minInt((i+1)*size, len(values))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accumulateShard(&shards[i], values[start:end], policy)
+		}()
+	}
+	wg.Wait()
+
+	var a Accumulator
+	for i := range shards {
+		a.Merge(&shards[i])
+	}
+	return a
+}
+
+// ParallelSum returns the sum of values computed by ParallelAccumulate. The result and any error are
+// those of Sum
+func ParallelSum(values []Fixed, policy NaNPolicy, workers int) (Fixed, error) {
+	a := ParallelAccumulate(values, policy, workers)
+	f, err := a.Fixed()
+	if err != nil {
+		return NaN, arithmeticError("ParallelSum", ErrOverflow)
+	}
+	return f, nil
+}
+
+// accumulateShard adds values to a, skipping NaN if policy says so
+func accumulateShard(a *Accumulator, values []Fixed, policy NaNPolicy) {
+	for _, f := range values {
+		if policy == SkipNaN && f.IsNaN() {
+			continue
+		}
+		a.Add(f)
+	}
+}
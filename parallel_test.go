@@ -0,0 +1,52 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestParallelSum(t *testing.T) {
+	values := make([]Fixed, 1<<20)
+	for i := range values {
+		values[i] = NewFromOriginal(uint64(i*7919) % 100000000000)
+	}
+	want, err := Sum(values, PropagateNaN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{0, 1, 3, 8, 64} {
+		f, err := ParallelSum(values, PropagateNaN, workers)
+		if err != nil || !f.Equal(want) {
+			t.Error("should be equal", workers, f, want, err)
+		}
+		a := ParallelAccumulate(values, PropagateNaN, workers)
+		if a.Count() != uint64(len(values)) {
+			t.Error("should be equal", workers, a.Count(), len(values))
+		}
+	}
+
+	// the first NaN wins whichever shard it is in
+	values[len(values)/2] = NewNaN(NaNMissing)
+	values[len(values)-1] = NewNaN(NaNParse)
+	for _, workers := range []int{1, 8} {
+		f, _ := ParallelSum(values, PropagateNaN, workers)
+		if reason, _ := f.NaNReason(); reason != NaNMissing {
+			t.Error("should be the first NaN", workers, reason)
+		}
+		if f, _ := ParallelSum(values, SkipNaN, workers); f.IsNaN() {
+			t.Error("should skip NaN", workers)
+		}
+	}
+
+	for i := range values {
+		values[i] = MAX
+	}
+	if _, err := ParallelSum(values, SkipNaN, 4); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	a := ParallelAccumulate(values, SkipNaN, 4)
+	if a.String() != "104857599999999999.98951424" {
+		t.Error("should be equal", a.String())
+	}
+}
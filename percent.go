@@ -0,0 +1,117 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+)
+
+var (
+	hundred     = Fixed{fp: 100 * scale}
+	tenThousand = Fixed{fp: 10000 * scale}
+)
+
+// NewFromPercent converts a percentage to a rate, for example 12.5 to 0.125. An error is returned if
+// the rate would have more than 8 decimal places
+func NewFromPercent(pct Fixed) (Fixed, error) {
+	if pct.IsNaN() || pct.IsInf() {
+		return pct, nil
+	}
+	if pct.fp%100 != 0 {
+		return NaN, arithmeticError("NewFromPercent", ErrInexact, pct)
+	}
+	return Fixed{fp: pct.fp / 100}, nil
+}
+
+// NewFromBps converts basis points to a rate, for example 7.5 to 0.00075. An error is returned if the
+// rate would have more than 8 decimal places
+func NewFromBps(bps Fixed) (Fixed, error) {
+	if bps.IsNaN() || bps.IsInf() {
+		return bps, nil
+	}
+	if bps.fp%10000 != 0 {
+		return NaN, arithmeticError("NewFromBps", ErrInexact, bps)
+	}
+	return Fixed{fp: bps.fp / 10000}, nil
+}
+
+// Percent converts a rate to a percentage, for example 0.125 to 12.5. An error is returned on overflow
+func (f Fixed) Percent() (Fixed, error) {
+	result, err := f.MulDiv(hundred, ONE, RoundDown)
+	return result, renameOp(err, "Percent", f)
+}
+
+// Bps converts a rate to basis points, for example 0.00075 to 7.5. An error is returned on overflow
+func (f Fixed) Bps() (Fixed, error) {
+	result, err := f.MulDiv(tenThousand, ONE, RoundDown)
+	return result, renameOp(err, "Bps", f)
+}
+
+// ApplyPercent returns pct percent of f, rounded once according to mode. For example 200 ApplyPercent 12.5
+// is 25
+func (f Fixed) ApplyPercent(pct Fixed, mode RoundingMode) (Fixed, error) {
+	result, err := f.MulDiv(pct, hundred, mode)
+	return result, renameOp(err, "ApplyPercent", f, pct)
+}
+
+// ApplyBps returns bps basis points of f, rounded once according to mode, such as the fee on a notional.
+// For example 20000 ApplyBps 7.5 is 15
+func (f Fixed) ApplyBps(bps Fixed, mode RoundingMode) (Fixed, error) {
+	result, err := f.MulDiv(bps, tenThousand, mode)
+	return result, renameOp(err, "ApplyBps", f, bps)
+}
+
+// PercentOf returns f as a percentage of whole, rounded according to mode. For example 25 PercentOf 200
+// is 12.5. An error is returned if whole is zero or on overflow
+func (f Fixed) PercentOf(whole Fixed, mode RoundingMode) (Fixed, error) {
+	result, err := f.MulDiv(hundred, whole, mode)
+	return result, renameOp(err, "PercentOf", f, whole)
+}
+
+// PercentChange returns the size of the change from from to to as a percentage of from, rounded
+// according to mode, and whether it is a fall. As a Fixed cannot be negative the direction is reported
+// separately. An error is returned if from is zero or on overflow
+func PercentChange(from, to Fixed, mode RoundingMode) (pct Fixed, negative bool, err error) {
+	if from.IsNaN() || to.IsNaN() {
+		return NaN, false, nil
+	}
+	if from.IsInf() || to.IsInf() {
+		return NaN, false, arithmeticError("PercentChange", ErrInf, from, to)
+	}
+	diff := Fixed{fp: to.fp - from.fp}
+	if from.fp > to.fp {
+		diff, negative = Fixed{fp: from.fp - to.fp}, true
+	}
+	pct, err = diff.MulDiv(hundred, from, mode)
+	if err != nil {
+		return NaN, false, renameOp(err, "PercentChange", from, to)
+	}
+	return pct, negative, nil
+}
+
+// Spread returns the bid ask spread in basis points of the mid price, (ask-bid) / ((ask+bid)/2) * 10000,
+// rounded once according to mode. An error is returned if the book is crossed, with bid above ask, or
+// both are zero
+func Spread(bid, ask Fixed, mode RoundingMode) (Fixed, error) {
+	if bid.IsNaN() || ask.IsNaN() {
+		return NaN, nil
+	}
+	if bid.IsInf() || ask.IsInf() {
+		return NaN, arithmeticError("Spread", ErrInf, bid, ask)
+	}
+	if bid.fp > ask.fp {
+		return NaN, arithmeticError("Spread", ErrNegative, bid, ask)
+	}
+	if ask.fp == 0 {
+		return NaN, arithmeticError("Spread", ErrDivisionByZero, bid, ask)
+	}
+	// in original units the spread is (ask-bid) * 2 * 10000 * 10^8 / (ask+bid)
+	num := new(big.Int).SetUint64(ask.fp - bid.fp)
+	num.Mul(num, big.NewInt(2*10000*int64(scale)))
+	den := new(big.Int).SetUint64(ask.fp)
+	result, err := fromBigQuo(num, den.Add(den, new(big.Int).SetUint64(bid.fp)), mode)
+	if err != nil {
+		return NaN, arithmeticError("Spread", ErrOverflow, bid, ask)
+	}
+	return result, nil
+}
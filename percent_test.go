@@ -0,0 +1,81 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestPercent(t *testing.T) {
+	rate, err := NewFromPercent(NewFromString("12.5"))
+	if err != nil || rate.String() != "0.125" {
+		t.Error("should be equal", rate, "0.125", err)
+	}
+	rate, err = NewFromBps(NewFromString("7.5"))
+	if err != nil || rate.String() != "0.00075" {
+		t.Error("should be equal", rate, "0.00075", err)
+	}
+	if _, err := NewFromBps(NewFromString("0.00001")); !errors.Is(err, ErrInexact) {
+		t.Error("should be inexact", err)
+	}
+	if f, _ := rate.Bps(); f.String() != "7.5" {
+		t.Error("should be equal", f, "7.5")
+	}
+	if f, _ := NewFromString("0.125").Percent(); f.String() != "12.5" {
+		t.Error("should be equal", f, "12.5")
+	}
+
+	f, err := NewFromString("20000").ApplyBps(NewFromString("7.5"), RoundHalfEven)
+	if err != nil || f.String() != "15" {
+		t.Error("should be equal", f, "15", err)
+	}
+	// 0.1 bps of 0.33333333 is 0.0000033333333, rounded once
+	f, _ = NewFromString("0.33333333").ApplyBps(NewFromString("0.1"), RoundUp)
+	if f.String() != "0.00000334" {
+		t.Error("should be equal", f, "0.00000334")
+	}
+	f, _ = NewFromString("200").ApplyPercent(NewFromString("12.5"), RoundDown)
+	if f.String() != "25" {
+		t.Error("should be equal", f, "25")
+	}
+	f, _ = ONE.PercentOf(THREE, RoundHalfUp)
+	if f.String() != "33.33333333" {
+		t.Error("should be equal", f, "33.33333333")
+	}
+	if _, err := ONE.PercentOf(ZERO, RoundDown); !errors.Is(err, ErrDivisionByZero) || err.Error() != "fixed: PercentOf(1, 0): division by zero" {
+		t.Error("should be division by zero", err)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	pct, negative, err := PercentChange(NewFromString("80"), NewFromString("100"), RoundDown)
+	if err != nil || negative || pct.String() != "25" {
+		t.Error("should be equal", pct, negative, "25", err)
+	}
+	pct, negative, _ = PercentChange(NewFromString("100"), NewFromString("80"), RoundDown)
+	if !negative || pct.String() != "20" {
+		t.Error("should be equal", pct, negative, "20")
+	}
+	if _, _, err := PercentChange(ZERO, ONE, RoundDown); !errors.Is(err, ErrDivisionByZero) {
+		t.Error("should be division by zero", err)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	f, err := Spread(NewFromString("99.99"), NewFromString("100.01"), RoundHalfEven)
+	if err != nil || f.String() != "2" {
+		t.Error("should be equal", f, "2", err)
+	}
+	f, _ = Spread(NewFromString("1"), NewFromString("1.0001"), RoundHalfUp)
+	if f.String() != "0.99995" {
+		t.Error("should be equal", f, "0.99995")
+	}
+	// no overflow when ask+bid exceeds MAX
+	f, err = Spread(MAX.Sub(ONE), MAX, RoundDown)
+	if err != nil || f.String() != "0.0000001" {
+		t.Error("should be equal", f, "0.0000001", err)
+	}
+	if _, err := Spread(TWO, ONE, RoundDown); !errors.Is(err, ErrNegative) {
+		t.Error("crossed book should fail", err)
+	}
+}
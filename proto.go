@@ -0,0 +1,307 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// protobuf wire types used by the messages below
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// MarshalProto encodes f as the fixed.Fixed protobuf message defined in fixed.proto
+func (f Fixed) MarshalProto() ([]byte, error) {
+	if f.fp == 0 {
+		return []byte{}, nil
+	}
+	return appendProtoVarint(nil, 1, f.fp), nil
+}
+
+// UnmarshalProto decodes a fixed.Fixed protobuf message produced by MarshalProto
+func (f *Fixed) UnmarshalProto(data []byte) error {
+	var fp uint64
+	err := readProto(data, func(field int, wire int, v uint64, _ []byte) error {
+		if field == 1 {
+			if wire != wireVarint {
+				return ErrFormat
+			}
+			fp = v
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	f.fp = fp
+	return nil
+}
+
+// DecimalString converts a Fixed to the value string of a google.type.Decimal. NaN and Inf are not
+// representable and return an error
+func (f Fixed) DecimalString() (string, error) {
+	if f.IsNaN() {
+		return "", ErrNaN
+	}
+	if f.IsInf() {
+		return "", ErrInf
+	}
+	return f.String(), nil
+}
+
+// NewFromDecimalString creates a Fixed from the value string of a google.type.Decimal, which
+// may carry a sign and an exponent, e.g. "+1.5e3". An error is returned if the value is
+// negative, too large, or has non-zero digits beyond the 8th decimal place
+func NewFromDecimalString(s string) (Fixed, error) {
+	neg := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i != -1 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return NaN, ErrFormat
+		}
+		exp = int(e)
+		s = s[:i]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart+fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return NaN, ErrFormat
+	}
+	f, err := fromDigits(intPart+fracPart, exp-len(fracPart))
+	if err != nil {
+		return NaN, err
+	}
+	if neg && f.fp != 0 {
+		return NaN, ErrNegative
+	}
+	return f, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// fromDigits creates a Fixed equal to digits * 10^exp, failing rather than dropping digits
+func fromDigits(digits string, exp int) (Fixed, error) {
+	digits = strings.TrimLeft(digits, "0")
+	for len(digits) > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		exp++
+	}
+	if digits == "" {
+		return ZERO, nil
+	}
+	shift := exp + nPlaces
+	if shift < 0 {
+		return NaN, ErrInexact
+	}
+	if len(digits)+shift > len(pow10) {
+		return NaN, ErrTooLarge
+	}
+	i, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return NaN, ErrTooLarge
+	}
+	hi, fp := bits.Mul64(i, pow10[shift])
+	if hi != 0 || fp > MAX.fp {
+		return NaN, ErrTooLarge
+	}
+	return Fixed{fp: fp}, nil
+}
+
+// MarshalDecimalProto encodes f as a google.type.Decimal protobuf message
+func (f Fixed) MarshalDecimalProto() ([]byte, error) {
+	s, err := f.DecimalString()
+	if err != nil {
+		return nil, err
+	}
+	return appendProtoBytes(nil, 1, []byte(s)), nil
+}
+
+// UnmarshalDecimalProto decodes a google.type.Decimal protobuf message
+func (f *Fixed) UnmarshalDecimalProto(data []byte) error {
+	var value string
+	err := readProto(data, func(field int, wire int, _ uint64, b []byte) error {
+		if field == 1 {
+			if wire != wireBytes {
+				return ErrFormat
+			}
+			value = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		f.fp = 0
+		return nil
+	}
+	fixed, err := NewFromDecimalString(value)
+	if err != nil {
+		return err
+	}
+	*f = fixed
+	return nil
+}
+
+// Money splits a Fixed into the units and nanos fields of a google.type.Money. NaN and Inf are not
+// representable and return an error
+func (f Fixed) Money() (units int64, nanos int32, err error) {
+	if f.IsNaN() {
+		return 0, 0, ErrNaN
+	}
+	if f.IsInf() {
+		return 0, 0, ErrInf
+	}
+	return int64(f.fp / scale), int32(f.fp%scale) * 10, nil
+}
+
+// NewFromMoney creates a Fixed from the units and nanos fields of a google.type.Money. An
+// error is returned for negative amounts, invalid nanos or a non-zero 9th decimal place
+func NewFromMoney(units int64, nanos int32) (Fixed, error) {
+	if units < 0 || nanos < 0 {
+		return NaN, ErrNegative
+	}
+	if nanos >= 1e9 {
+		return NaN, ErrFormat
+	}
+	if nanos%10 != 0 {
+		return NaN, ErrInexact
+	}
+	if uint64(units) > MAX.fp/scale {
+		return NaN, ErrTooLarge
+	}
+	return Fixed{fp: uint64(units)*scale + uint64(nanos/10)}, nil
+}
+
+// MarshalMoneyProto encodes f as a google.type.Money protobuf message in the given ISO 4217 currency
+func (f Fixed) MarshalMoneyProto(currency string) ([]byte, error) {
+	units, nanos, err := f.Money()
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	if currency != "" {
+		b = appendProtoBytes(b, 1, []byte(currency))
+	}
+	if units != 0 {
+		b = appendProtoVarint(b, 2, uint64(units))
+	}
+	if nanos != 0 {
+		b = appendProtoVarint(b, 3, uint64(nanos))
+	}
+	if b == nil {
+		b = []byte{}
+	}
+	return b, nil
+}
+
+// UnmarshalMoneyProto decodes a google.type.Money protobuf message, returning the amount and currency code
+func UnmarshalMoneyProto(data []byte) (Fixed, string, error) {
+	var currency string
+	var units int64
+	var nanos int32
+	err := readProto(data, func(field int, wire int, v uint64, b []byte) error {
+		switch field {
+		case 1:
+			if wire != wireBytes {
+				return ErrFormat
+			}
+			currency = string(b)
+		case 2:
+			if wire != wireVarint {
+				return ErrFormat
+			}
+			units = int64(v)
+		case 3:
+			if wire != wireVarint {
+				return ErrFormat
+			}
+			nanos = int32(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return NaN, "", err
+	}
+	f, err := NewFromMoney(units, nanos)
+	if err != nil {
+		return NaN, "", err
+	}
+	return f, currency, nil
+}
+
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// readProto walks the fields of a protobuf message, calling fn with the varint value or the
+// bytes of each field. Fixed width fields are passed as bytes and unknown fields are left to fn to ignore
+func readProto(data []byte, fn func(field int, wire int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key>>3 == 0 || key>>3 > 1<<29-1 {
+			return ErrFormat
+		}
+		data = data[n:]
+		field, wire := int(key>>3), int(key&7)
+		var v uint64
+		var b []byte
+		switch wire {
+		case wireVarint:
+			v, n = binary.Uvarint(data)
+			if n <= 0 {
+				return ErrFormat
+			}
+			data = data[n:]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wire == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return ErrFormat
+			}
+			b, data = data[:size], data[size:]
+		case wireBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || l > uint64(len(data)-n) {
+				return ErrFormat
+			}
+			b, data = data[n:n+int(l)], data[n+int(l):]
+		default:
+			return ErrFormat
+		}
+		if err := fn(field, wire, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
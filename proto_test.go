@@ -0,0 +1,148 @@
+package fixed_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func golden(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestProto(t *testing.T) {
+	f := NewFromString("12345.12345")
+	data, err := f.MarshalProto()
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(data, golden(t, "08a8efadf5f623")) {
+		t.Error("should be equal", hex.EncodeToString(data), "08a8efadf5f623")
+	}
+	var f0 Fixed
+	if err := f0.UnmarshalProto(data); err != nil {
+		t.Error(err)
+	}
+	if !f.Equal(f0) {
+		t.Error("don't match", f, f0)
+	}
+
+	data, _ = NaN.MarshalProto()
+	if !bytes.Equal(data, golden(t, "08ffffffffffffffffff01")) {
+		t.Error("should be equal", hex.EncodeToString(data), "08ffffffffffffffffff01")
+	}
+	_ = f0.UnmarshalProto(data)
+	if !f0.IsNaN() {
+		t.Error("f0 should be NaN")
+	}
+
+	data, _ = ZERO.MarshalProto()
+	if len(data) != 0 {
+		t.Error("zero should encode as an empty message", hex.EncodeToString(data))
+	}
+	f0 = ONE
+	_ = f0.UnmarshalProto(data)
+	if !f0.IsZero() {
+		t.Error("should be zero", f0)
+	}
+
+	// unknown fields are skipped
+	_ = f0.UnmarshalProto(golden(t, "1203616263"+"08a8efadf5f623"))
+	if !f.Equal(f0) {
+		t.Error("don't match", f, f0)
+	}
+
+	if err := f0.UnmarshalProto(golden(t, "08ff")); err == nil {
+		t.Error("truncated message should fail")
+	}
+}
+
+func TestDecimalProto(t *testing.T) {
+	f := NewFromString("12345.12345")
+	data, err := f.MarshalDecimalProto()
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(data, golden(t, "0a0b31323334352e3132333435")) {
+		t.Error("should be equal", hex.EncodeToString(data), "0a0b31323334352e3132333435")
+	}
+	var f0 Fixed
+	if err := f0.UnmarshalDecimalProto(data); err != nil {
+		t.Error(err)
+	}
+	if !f.Equal(f0) {
+		t.Error("don't match", f, f0)
+	}
+
+	if _, err := NaN.MarshalDecimalProto(); err == nil {
+		t.Error("NaN should not encode")
+	}
+
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"2.5", "2.5"},
+		{"+2.5", "2.5"},
+		{"-0", "0"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"1.5e3", "1500"},
+		{"1E-8", "0.00000001"},
+		{"0.000000010", "0.00000001"},
+		{"99999999999.99999999", "99999999999.99999999"},
+	}
+	for _, test := range tests {
+		f, err := NewFromDecimalString(test.s)
+		if err != nil {
+			t.Error(test.s, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Error("should be equal", f.String(), test.want)
+		}
+	}
+
+	for _, s := range []string{"", ".", "-1", "1e", "1.2.3", "abc", "1e-9", "0.123456789", "100000000000", "NaN"} {
+		if _, err := NewFromDecimalString(s); err == nil {
+			t.Error("should fail", s)
+		}
+	}
+}
+
+func TestMoneyProto(t *testing.T) {
+	f := NewFromString("12345.12345")
+	data, err := f.MarshalMoneyProto("USD")
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(data, golden(t, "0a0355534410b9601890e5ee3a")) {
+		t.Error("should be equal", hex.EncodeToString(data), "0a0355534410b9601890e5ee3a")
+	}
+	f0, currency, err := UnmarshalMoneyProto(data)
+	if err != nil {
+		t.Error(err)
+	}
+	if !f.Equal(f0) || currency != "USD" {
+		t.Error("don't match", f, f0, currency)
+	}
+
+	units, nanos, _ := NewFromString("1.75").Money()
+	if units != 1 || nanos != 750000000 {
+		t.Error("should be equal", units, nanos, 1, 750000000)
+	}
+	if _, err := NewFromMoney(1, 1); err == nil {
+		t.Error("9th decimal place should fail")
+	}
+	if _, err := NewFromMoney(-1, -500000000); err == nil {
+		t.Error("negative should fail")
+	}
+	if _, _, err := NaN.Money(); err == nil {
+		t.Error("NaN should fail")
+	}
+}
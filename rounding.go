@@ -0,0 +1,78 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+)
+
+// RoundingMode selects how a result with more than 8 decimal places is rounded. As a Fixed is never
+// negative, RoundDown and RoundUp are also floor and ceiling
+type RoundingMode int
+
+const (
+	// RoundDown truncates towards zero
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundHalfUp rounds to nearest, with ties away from zero
+	RoundHalfUp
+	// RoundHalfDown rounds to nearest, with ties towards zero
+	RoundHalfDown
+	// RoundHalfEven rounds to nearest, with ties to the even neighbour (banker's rounding)
+	RoundHalfEven
+)
+
+func (mode RoundingMode) String() string {
+	switch mode {
+	case RoundDown:
+		return "RoundDown"
+	case RoundUp:
+		return "RoundUp"
+	case RoundHalfUp:
+		return "RoundHalfUp"
+	case RoundHalfDown:
+		return "RoundHalfDown"
+	case RoundHalfEven:
+		return "RoundHalfEven"
+	}
+	return "RoundingMode(?)"
+}
+
+// roundUp reports whether the quotient q of a division by d leaving remainder r should be
+// incremented to round it according to mode
+func (mode RoundingMode) roundUp(q, r, d uint64) bool {
+	if r == 0 {
+		return false
+	}
+	switch mode {
+	case RoundUp:
+		return true
+	case RoundHalfUp:
+		return r >= d-r
+	case RoundHalfDown:
+		return r > d-r
+	case RoundHalfEven:
+		return r > d-r || r == d-r && q&1 == 1
+	}
+	return false
+}
+
+// roundUpBig is roundUp for big integers
+func (mode RoundingMode) roundUpBig(q, r, d *big.Int) bool {
+	if r.Sign() == 0 {
+		return false
+	}
+	half := new(big.Int).Lsh(r, 1).Cmp(d)
+	switch mode {
+	case RoundUp:
+		return true
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundHalfEven:
+		return half > 0 || half == 0 && q.Bit(0) == 1
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/bits"
+)
+
+// AddSat adds f0 to f, clamping the result to MAX where Add would panic on overflow. The boolean
+// reports whether the result was clamped. NaN and Inf operands give the result of Add, unclamped
+func (f Fixed) AddSat(f0 Fixed) (Fixed, bool) {
+	if result, done, _ := special('+', f, f0); done {
+		return result, false
+	}
+	result, ok := f.add(f0)
+	if !ok {
+		return MAX, true
+	}
+	return result, false
+}
+
+// SubSat subtracts f0 from f, clamping the result to ZERO rather than panicking when f0 is greater than f.
+// The boolean reports whether the result was clamped. NaN and Inf operands give the result of Sub, except
+// that a finite value minus Inf is clamped to ZERO
+func (f Fixed) SubSat(f0 Fixed) (Fixed, bool) {
+	if result, done, err := special('-', f, f0); done {
+		if err != nil {
+			return ZERO, true
+		}
+		return result, false
+	}
+	result, ok := f.sub(f0)
+	if !ok {
+		return ZERO, true
+	}
+	return result, false
+}
+
+// MulSat multiplies f by f0, clamping the result to MAX where Mul would panic on overflow. The boolean
+// reports whether the result was clamped. NaN and Inf operands give the result of Mul, unclamped
+func (f Fixed) MulSat(f0 Fixed) (Fixed, bool) {
+	if result, done, _ := special('*', f, f0); done {
+		return result, false
+	}
+	result, ok := f.mul(f0)
+	if !ok {
+		return MAX, true
+	}
+	return result, false
+}
+
+// DivSat divides f by f0 exactly, truncating to 8 places, and clamps the result to MAX when a tiny or
+// zero divisor would overflow. The boolean reports whether the result was clamped. If both operands are
+// zero, NaN is returned. NaN and Inf operands give the result of Div, unclamped
+func (f Fixed) DivSat(f0 Fixed) (Fixed, bool) {
+	if result, done, _ := special('/', f, f0); done {
+		return result, false
+	}
+	if f0.fp == 0 {
+		if f.fp == 0 {
+			return NewNaN(NaNDivisionByZero), false
+		}
+		return MAX, true
+	}
+	hi, lo := bits.Mul64(f.fp, scale)
+	if hi >= f0.fp {
+		return MAX, true
+	}
+	result, _ := bits.Div64(hi, lo, f0.fp)
+	if result > MAX.fp {
+		return MAX, true
+	}
+	return Fixed{fp: result}, false
+}
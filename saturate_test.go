@@ -0,0 +1,108 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestSaturate(t *testing.T) {
+	f, sat := NewFromString("1.5").AddSat(ONE)
+	if sat || f.String() != "2.5" {
+		t.Error("should be equal", f, sat, "2.5")
+	}
+	f, sat = MAX.AddSat(ONE)
+	if !sat || !f.Equal(MAX) {
+		t.Error("should saturate", f, sat)
+	}
+	f, sat = NewFromOriginal(1 << 63).AddSat(NewFromOriginal(1 << 63))
+	if !sat || !f.Equal(MAX) {
+		t.Error("should saturate", f, sat)
+	}
+
+	f, sat = TWO.SubSat(THREE)
+	if !sat || !f.IsZero() {
+		t.Error("should saturate", f, sat)
+	}
+	f, sat = THREE.SubSat(TWO)
+	if sat || !f.Equal(ONE) {
+		t.Error("should be equal", f, sat, ONE)
+	}
+
+	f, sat = NewFromString("18.44674408").MulSat(NewFromString("9999999999.99999999"))
+	if !sat || !f.Equal(MAX) {
+		t.Error("should saturate", f, sat)
+	}
+	f, sat = NewFromString("123.456").MulSat(NewFromString("1000"))
+	if sat || f.String() != "123456" {
+		t.Error("should be equal", f, sat, "123456")
+	}
+
+	f, sat = ONE.DivSat(NewFromString("0.00000001"))
+	if sat || f.String() != "100000000" {
+		t.Error("should be equal", f, sat, "100000000")
+	}
+	f, sat = NewFromString("1000").DivSat(NewFromString("0.00000001"))
+	if !sat || !f.Equal(MAX) {
+		t.Error("should saturate", f, sat)
+	}
+	f, sat = ONE.DivSat(ZERO)
+	if !sat || !f.Equal(MAX) {
+		t.Error("should saturate", f, sat)
+	}
+	f, sat = TWO.DivSat(THREE)
+	if sat || f.String() != "0.66666666" {
+		t.Error("should be equal", f, sat, "0.66666666")
+	}
+	if f, _ := ZERO.DivSat(ZERO); !f.IsNaN() {
+		t.Error("should be NaN")
+	}
+	if f, sat := NaN.AddSat(ONE); !f.IsNaN() || sat {
+		t.Error("should be NaN")
+	}
+}
+
+func TestOverflowBound(t *testing.T) {
+	// results between MAX and the reserved encodings overflow Add and Mul, and saturate AddSat and MulSat
+	ulp := NewFromString("0.00000001")
+	tests := []struct {
+		op   string
+		f    Fixed
+		f0   Fixed
+		over bool
+	}{
+		{"+", MAX, ulp, true},
+		{"+", MAX.Sub(ulp), ulp, false},
+		{"+", MAX, NewFromString("1000"), true},
+		{"*", MAX, NewFromString("1.00000001"), true},
+		{"*", NewFromString("49999999999.99999999"), TWO, false},
+		{"*", NewFromString("50000000000"), TWO, true},
+	}
+	for _, test := range tests {
+		var sat bool
+		var err error
+		func() {
+			defer func() { err, _ = recover().(error) }()
+			if test.op == "+" {
+				_, sat = test.f.AddSat(test.f0)
+				_ = test.f.Add(test.f0)
+			} else {
+				_, sat = test.f.MulSat(test.f0)
+				_ = test.f.Mul(test.f0)
+			}
+		}()
+		if sat != test.over || (err != nil) != test.over || err != nil && !errors.Is(err, ErrOverflow) {
+			t.Error("should agree", test.op, test.f, test.f0, sat, err)
+		}
+	}
+
+	var a AtomicFixed
+	a.Store(MAX)
+	if _, err := a.Add(ulp); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	dst := make([]Fixed, 1)
+	if err := AddVec(dst, []Fixed{MAX}, []Fixed{ulp}); !errors.Is(err, ErrOverflow) || !dst[0].IsNaN() {
+		t.Error("should overflow", dst, err)
+	}
+}
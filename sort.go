@@ -0,0 +1,59 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"slices"
+	"sort"
+)
+
+// Compare returns a.Cmp(b), for use with slices.SortFunc, slices.BinarySearchFunc and the like. Values
+// are ordered as by Cmp: ascending, then Inf, then NaN, with every NaN equal to every other
+func Compare(a, b Fixed) int {
+	return a.Cmp(b)
+}
+
+// Slice attaches the methods of sort.Interface to []Fixed, sorting in the order of Compare
+type Slice []Fixed
+
+func (s Slice) Len() int           { return len(s) }
+func (s Slice) Less(i, j int) bool { return s[i].Cmp(s[j]) < 0 }
+func (s Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort is a convenience method: s.Sort() calls sort.Sort(s)
+func (s Slice) Sort() {
+	sort.Sort(s)
+}
+
+// Search returns the result of applying BinarySearch to s
+func (s Slice) Search(f Fixed) (int, bool) {
+	return BinarySearch(s, f)
+}
+
+// BinarySearch searches for f in values, which must be sorted in the order of Compare, returning the
+// position where f is found, or would be inserted, and whether it was found
+func BinarySearch(values []Fixed, f Fixed) (int, bool) {
+	return slices.BinarySearchFunc(values, f, Compare)
+}
+
+// Min returns the smaller of f and f0 in the order of Cmp, so NaN is only returned if both are NaN
+func (f Fixed) Min(f0 Fixed) Fixed {
+	if f0.Cmp(f) < 0 {
+		return f0
+	}
+	return f
+}
+
+// Max returns the larger of f and f0 in the order of Cmp, so NaN is returned if either is NaN
+func (f Fixed) Max(f0 Fixed) Fixed {
+	if f0.Cmp(f) > 0 {
+		return f0
+	}
+	return f
+}
+
+// Clamp returns f limited to the range lo to hi in the order of Cmp, so a NaN f becomes hi. If lo is
+// greater than hi, hi is returned
+func (f Fixed) Clamp(lo, hi Fixed) Fixed {
+	return f.Max(lo).Min(hi)
+}
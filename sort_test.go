@@ -0,0 +1,58 @@
+package fixed_test
+
+import (
+	. "github.com/cryptowrold/fixed"
+	"slices"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	values := []Fixed{NewFromString("2.5"), NaN, Inf, ZERO, NewFromString("0.1"), MAX}
+	want := []string{"0", "0.1", "2.5", MAX.String(), "Inf", "NaN"}
+
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, Compare)
+	s := Slice(slices.Clone(values))
+	s.Sort()
+	for i := range want {
+		if sorted[i].String() != want[i] || s[i].String() != want[i] {
+			t.Error("should be equal", i, sorted[i], s[i], want[i])
+		}
+	}
+
+	i, found := BinarySearch(sorted, NewFromString("2.5"))
+	if i != 2 || !found {
+		t.Error("should be found", i, found)
+	}
+	i, found = s.Search(NewFromString("1"))
+	if i != 2 || found {
+		t.Error("should not be found", i, found)
+	}
+	if i, found = BinarySearch(sorted, NewNaN(NaNParse)); i != 5 || !found {
+		t.Error("NaN should be found", i, found)
+	}
+}
+
+func TestMinMaxClamp(t *testing.T) {
+	if !ONE.Min(TWO).Equal(ONE) || !TWO.Min(ONE).Equal(ONE) || !ONE.Min(NaN).Equal(ONE) {
+		t.Error("Min should be ONE")
+	}
+	if !ONE.Max(TWO).Equal(TWO) || !ONE.Max(Inf).IsInf() || !ONE.Max(NaN).IsNaN() {
+		t.Error("Max should follow Cmp")
+	}
+	tests := []struct {
+		f    Fixed
+		want Fixed
+	}{
+		{ZERO, ONE},
+		{TWO, TWO},
+		{TEN, THREE},
+		{Inf, THREE},
+		{NaN, THREE},
+	}
+	for _, test := range tests {
+		if f := test.f.Clamp(ONE, THREE); f.Cmp(test.want) != 0 {
+			t.Error("should be equal", test.f, f, test.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// RoundToStep rounds f to a multiple of step, such as a tick or lot size, according to mode. It is
+// computed exactly in integer arithmetic. An error is returned if step is zero, NaN or Inf, or the
+// rounded value is too large. NaN and Inf are returned unchanged
+func (f Fixed) RoundToStep(step Fixed, mode RoundingMode) (Fixed, error) {
+	if step.IsNaN() || step.IsInf() {
+		return NaN, arithmeticError("RoundToStep", ErrInvalidOperation, f, step)
+	}
+	if step.fp == 0 {
+		return NaN, arithmeticError("RoundToStep", ErrDivisionByZero, f, step)
+	}
+	if f.IsNaN() || f.IsInf() {
+		return f, nil
+	}
+	q, r := f.fp/step.fp, f.fp%step.fp
+	if mode.roundUp(q, r, step.fp) {
+		q++
+	}
+	hi, fp := bits.Mul64(q, step.fp)
+	if hi != 0 || fp > MAX.fp {
+		return NaN, arithmeticError("RoundToStep", ErrOverflow, f, step)
+	}
+	return Fixed{fp: fp}, nil
+}
+
+// FloorToStep returns the largest multiple of step not greater than f, as RoundToStep with RoundDown
+func (f Fixed) FloorToStep(step Fixed) (Fixed, error) {
+	return f.RoundToStep(step, RoundDown)
+}
+
+// CeilToStep returns the smallest multiple of step not less than f, as RoundToStep with RoundUp
+func (f Fixed) CeilToStep(step Fixed) (Fixed, error) {
+	return f.RoundToStep(step, RoundUp)
+}
+
+// IsMultipleOf reports whether f is an exact multiple of step. It is false if step is zero, or either
+// is NaN or Inf
+func (f Fixed) IsMultipleOf(step Fixed) bool {
+	if f.IsNaN() || f.IsInf() || step.IsNaN() || step.IsInf() || step.fp == 0 {
+		return false
+	}
+	return f.fp%step.fp == 0
+}
+
+// Ladder returns the sequence from, from+step, from+2*step and so on while not beyond to, such as the
+// price levels of a grid of orders. If from is greater than to the ladder descends instead. from is not
+// moved onto the grid of step; use FloorToStep or CeilToStep first if it must be. The sequence is empty
+// if step is zero, or any argument is NaN or Inf
+func Ladder(from, to, step Fixed) iter.Seq[Fixed] {
+	return func(yield func(Fixed) bool) {
+		for _, f := range []Fixed{from, to, step} {
+			if f.IsNaN() || f.IsInf() {
+				return
+			}
+		}
+		if step.fp == 0 {
+			return
+		}
+		if from.fp <= to.fp {
+			for fp := from.fp; ; fp += step.fp {
+				if !yield(Fixed{fp: fp}) || to.fp-fp < step.fp {
+					return
+				}
+			}
+		}
+		for fp := from.fp; ; fp -= step.fp {
+			if !yield(Fixed{fp: fp}) || fp-to.fp < step.fp {
+				return
+			}
+		}
+	}
+}
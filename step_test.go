@@ -0,0 +1,89 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"slices"
+	"testing"
+)
+
+func TestRoundToStep(t *testing.T) {
+	tick := NewFromString("0.05")
+	tests := []struct {
+		f    string
+		mode RoundingMode
+		want string
+	}{
+		{"101.23", RoundDown, "101.2"},
+		{"101.23", RoundUp, "101.25"},
+		{"101.225", RoundHalfUp, "101.25"},
+		{"101.225", RoundHalfDown, "101.2"},
+		{"101.225", RoundHalfEven, "101.2"},
+		{"101.275", RoundHalfEven, "101.3"},
+		{"101.25", RoundUp, "101.25"},
+	}
+	for _, test := range tests {
+		f, err := NewFromString(test.f).RoundToStep(tick, test.mode)
+		if err != nil || f.String() != test.want {
+			t.Error("should be equal", test.f, test.mode, f, test.want, err)
+		}
+	}
+
+	lot := NewFromString("0.001")
+	f, _ := NewFromString("1.23456").FloorToStep(lot)
+	if f.String() != "1.234" {
+		t.Error("should be equal", f, "1.234")
+	}
+	f, _ = NewFromString("1.23456").CeilToStep(lot)
+	if f.String() != "1.235" {
+		t.Error("should be equal", f, "1.235")
+	}
+	if !f.IsMultipleOf(lot) || NewFromString("1.2345").IsMultipleOf(lot) || f.IsMultipleOf(ZERO) {
+		t.Error("IsMultipleOf is wrong")
+	}
+
+	if _, err := ONE.RoundToStep(ZERO, RoundDown); !errors.Is(err, ErrDivisionByZero) {
+		t.Error("should be division by zero", err)
+	}
+	if _, err := MAX.CeilToStep(TEN); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	if f, _ := NaN.FloorToStep(lot); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestLadder(t *testing.T) {
+	var got []string
+	for f := range Ladder(NewFromString("99.5"), NewFromString("100.8"), NewFromString("0.5")) {
+		got = append(got, f.String())
+	}
+	if !slices.Equal(got, []string{"99.5", "100", "100.5"}) {
+		t.Error("should be equal", got)
+	}
+
+	got = nil
+	for f := range Ladder(ONE, ZERO, NewFromString("0.25")) {
+		got = append(got, f.String())
+	}
+	if !slices.Equal(got, []string{"1", "0.75", "0.5", "0.25", "0"}) {
+		t.Error("should be equal", got)
+	}
+
+	// the ladder stops at MAX rather than overflowing
+	n := 0
+	for range Ladder(MAX.Sub(TWO), MAX, ONE) {
+		n++
+	}
+	if n != 3 {
+		t.Error("should be equal", n, 3)
+	}
+	for range Ladder(ONE, TEN, ZERO) {
+		t.Fatal("should be empty")
+	}
+	for f := range Ladder(ONE, TEN, ONE) {
+		if f.Equal(THREE) {
+			break
+		}
+	}
+}
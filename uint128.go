@@ -0,0 +1,105 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/bits"
+)
+
+// uint128 is an unsigned 128 bit integer used for wide intermediates
+type uint128 struct {
+	hi, lo uint64
+}
+
+// add returns u+v, reporting false on overflow
+func (u uint128) add(v uint128) (uint128, bool) {
+	lo, carry := bits.Add64(u.lo, v.lo, 0)
+	hi, carry := bits.Add64(u.hi, v.hi, carry)
+	return uint128{hi: hi, lo: lo}, carry == 0
+}
+
+// mul64 returns u*v, reporting false on overflow
+func (u uint128) mul64(v uint64) (uint128, bool) {
+	hi, lo := bits.Mul64(u.lo, v)
+	carry, mid := bits.Mul64(u.hi, v)
+	hi, c := bits.Add64(hi, mid, 0)
+	return uint128{hi: hi, lo: lo}, carry == 0 && c == 0
+}
+
+// quoRem64 returns u/v and u%v
+func (u uint128) quoRem64(v uint64) (uint128, uint64) {
+	qhi, r := bits.Div64(0, u.hi, v)
+	qlo, r := bits.Div64(r, u.lo, v)
+	return uint128{hi: qhi, lo: qlo}, r
+}
+
+// mulPow10 returns u*10^n, reporting false on overflow
+func (u uint128) mulPow10(n int) (uint128, bool) {
+	for n > 0 {
+		step := minInt(n, len(pow10)-1)
+		var ok bool
+		if u, ok = u.mul64(pow10[step]); !ok {
+			return u, false
+		}
+		n -= step
+	}
+	return u, true
+}
+
+// quoRemPow10 returns u/10^n, and whether the division was exact
+func (u uint128) quoRemPow10(n int) (uint128, bool) {
+	exact := true
+	for n > 0 {
+		step := minInt(n, len(pow10)-1)
+		var r uint64
+		u, r = u.quoRem64(pow10[step])
+		exact = exact && r == 0
+		n -= step
+	}
+	return u, exact
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi || u.hi == v.hi && u.lo < v.lo:
+		return -1
+	case u == v:
+		return 0
+	}
+	return 1
+}
+
+// bitLen returns the number of bits required to represent u
+func (u uint128) bitLen() int {
+	if u.hi != 0 {
+		return 64 + bits.Len64(u.hi)
+	}
+	return bits.Len64(u.lo)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// sqrt returns the integer square root of u, computed by Newton iteration
+func (u uint128) sqrt() uint64 {
+	if u.hi == 0 && u.lo < 2 {
+		return u.lo
+	}
+	// start above the root so the iteration decreases monotonically
+	x := uint64(1) << ((u.bitLen() + 1) / 2)
+	if x == 0 {
+		x = 1<<64 - 1
+	}
+	for {
+		q, _ := u.quoRem64(x)
+		y := x/2 + q.lo/2 + x&q.lo&1
+		if y >= x {
+			return x
+		}
+		x = y
+	}
+}
@@ -0,0 +1,80 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"math/big"
+)
+
+// NewFromUint256 creates a Fixed from an on-chain uint256 amount, given as 32 big endian bytes, of a
+// token with the given number of decimals. For example 1500000 with 6 decimals becomes 1.5.
+// See NewFromTokenAmount for the handling of truncation and overflow
+func NewFromUint256(b [32]byte, decimals uint8) (Fixed, error) {
+	return NewFromTokenAmount(new(big.Int).SetBytes(b[:]), decimals)
+}
+
+// NewFromTokenAmount creates a Fixed from an integer amount of a token with the given number of
+// decimals. If the amount has non-zero digits beyond the 8th decimal place, the truncated Fixed is
+// returned together with an error, so callers may choose to accept it. If the amount is negative
+// or too large, NaN and an error are returned
+func NewFromTokenAmount(x *big.Int, decimals uint8) (Fixed, error) {
+	if x.Sign() < 0 {
+		return NaN, ErrNegative
+	}
+	var err error
+	q := new(big.Int)
+	if decimals > nPlaces {
+		r := new(big.Int)
+		q.QuoRem(x, bigPow10(int(decimals-nPlaces)), r)
+		if r.Sign() != 0 {
+			err = ErrInexact
+		}
+	} else {
+		q.Mul(x, bigPow10(int(nPlaces-decimals)))
+	}
+	if !q.IsUint64() || q.Uint64() > MAX.fp {
+		return NaN, ErrTooLarge
+	}
+	return Fixed{fp: q.Uint64()}, err
+}
+
+// Uint256 converts a Fixed to a 32 byte big endian on-chain amount of a token with the given number
+// of decimals. If decimals is less than 8 and f has digits beyond it, the truncated amount is
+// returned together with an error. NaN, Inf, or an amount which does not fit 256 bits, returns an error
+func (f Fixed) Uint256(decimals uint8) ([32]byte, error) {
+	var b [32]byte
+	x, err := f.TokenAmount(decimals)
+	if x == nil {
+		return b, err
+	}
+	if x.BitLen() > 256 {
+		return b, ErrTooLarge
+	}
+	x.FillBytes(b[:])
+	return b, err
+}
+
+// TokenAmount converts a Fixed to an integer amount of a token with the given number of decimals,
+// for example 1.5 with 18 decimals becomes 1500000000000000000. Truncation is reported as for Uint256
+func (f Fixed) TokenAmount(decimals uint8) (*big.Int, error) {
+	if f.IsNaN() {
+		return nil, ErrNaN
+	}
+	if f.IsInf() {
+		return nil, ErrInf
+	}
+	x := new(big.Int).SetUint64(f.fp)
+	if decimals >= nPlaces {
+		return x.Mul(x, bigPow10(int(decimals-nPlaces))), nil
+	}
+	r := new(big.Int)
+	x.QuoRem(x, bigPow10(int(nPlaces-decimals)), r)
+	if r.Sign() != 0 {
+		return x, ErrInexact
+	}
+	return x, nil
+}
+
+func bigPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
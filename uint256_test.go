@@ -0,0 +1,68 @@
+package fixed_test
+
+import (
+	"encoding/hex"
+	. "github.com/cryptowrold/fixed"
+	"math/big"
+	"testing"
+)
+
+func TestUint256(t *testing.T) {
+	var b [32]byte
+	copy(b[:], golden(t, "00000000000000000000000000000000000000000000000014d1120d7b160000"))
+
+	f, err := NewFromUint256(b, 18)
+	if err != nil {
+		t.Error(err)
+	}
+	if f.String() != "1.5" {
+		t.Error("should be equal", f, "1.5")
+	}
+	b0, err := f.Uint256(18)
+	if err != nil {
+		t.Error(err)
+	}
+	if b0 != b {
+		t.Error("don't match", hex.EncodeToString(b0[:]), hex.EncodeToString(b[:]))
+	}
+
+	// USDC style 6 decimals
+	f, err = NewFromTokenAmount(big.NewInt(1234567), 6)
+	if err != nil || f.String() != "1.234567" {
+		t.Error("should be equal", f, "1.234567", err)
+	}
+	x, err := f.TokenAmount(6)
+	if err != nil || x.Int64() != 1234567 {
+		t.Error("should be equal", x, 1234567, err)
+	}
+
+	// truncation is reported, but the truncated value is returned
+	x, _ = new(big.Int).SetString("1000000000000000001", 10)
+	f, err = NewFromTokenAmount(x, 18)
+	if err == nil {
+		t.Error("should report truncation")
+	}
+	if f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	x, err = NewFromString("1.23").TokenAmount(1)
+	if err == nil || x.Int64() != 12 {
+		t.Error("should report truncation", x, err)
+	}
+
+	// overflow
+	x, _ = new(big.Int).SetString("100000000000000000000000000000", 10)
+	f, err = NewFromTokenAmount(x, 18)
+	if err == nil || !f.IsNaN() {
+		t.Error("should overflow", f, err)
+	}
+	if _, err := MAX.Uint256(255); err == nil {
+		t.Error("should overflow 256 bits")
+	}
+	if _, err := NewFromTokenAmount(big.NewInt(-1), 18); err == nil {
+		t.Error("should be negative")
+	}
+	if _, err := NaN.Uint256(18); err == nil {
+		t.Error("NaN should fail")
+	}
+}
@@ -0,0 +1,161 @@
+package fixed
+
+// release under the terms of file license.txt
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// The vector functions below apply an operation element by element, writing to dst, which may be the
+// same slice as an operand. Each element first takes a fast path of plain integer arithmetic with a
+// single range test, avoiding the NaN and Inf dispatch of the Fixed methods; a rare element which
+// overflows, or has a NaN or Inf operand, falls back to vecSlow with the full rules of the method. An
+// element which overflows is set to NaN and the batch carries on, with the first such element reported
+// as an error once the batch is done, wrapping an *ArithmeticError
+
+// AddVec sets dst[i] = a[i] + b[i]. An error is returned if the slices differ in length or an element overflows
+func AddVec(dst, a, b []Fixed) error {
+	if len(a) != len(dst) || len(b) != len(dst) {
+		return arithmeticError("AddVec", ErrLength)
+	}
+	a, b = a[:len(dst)], b[:len(dst)]
+	failed := -1
+	for i := range dst {
+		x, y := a[i].fp, b[i].fp
+		s := x + y
+		if s < x || s > MAX.fp {
+			s, failed = vecSlow('+', a[i], b[i], i, failed)
+		}
+		dst[i].fp = s
+	}
+	return vecError("AddVec", failed)
+}
+
+// SubVec sets dst[i] = a[i] - b[i]. An error is returned if the slices differ in length or an element
+// would be negative
+func SubVec(dst, a, b []Fixed) error {
+	if len(a) != len(dst) || len(b) != len(dst) {
+		return arithmeticError("SubVec", ErrLength)
+	}
+	a, b = a[:len(dst)], b[:len(dst)]
+	failed := -1
+	for i := range dst {
+		x, y := a[i].fp, b[i].fp
+		if x < y || x >= reserved || y >= reserved {
+			dst[i].fp, failed = vecSlow('-', a[i], b[i], i, failed)
+			continue
+		}
+		dst[i].fp = x - y
+	}
+	return vecError("SubVec", failed)
+}
+
+// MulVec sets dst[i] = a[i] * b[i], truncated as Mul. An error is returned if the slices differ in length
+// or an element overflows
+func MulVec(dst, a, b []Fixed) error {
+	if len(a) != len(dst) || len(b) != len(dst) {
+		return arithmeticError("MulVec", ErrLength)
+	}
+	a, b = a[:len(dst)], b[:len(dst)]
+	failed := -1
+	for i := range dst {
+		x, y := a[i].fp, b[i].fp
+		hi, lo := bits.Mul64(x, y)
+		if hi != 0 || x >= reserved || y >= reserved {
+			dst[i].fp, failed = vecSlow('*', a[i], b[i], i, failed)
+			continue
+		}
+		dst[i].fp = lo / scale
+	}
+	return vecError("MulVec", failed)
+}
+
+// ScaleVec sets dst[i] = a[i] * k, truncated as Mul. An error is returned if the slices differ in length
+// or an element overflows
+func ScaleVec(dst, a []Fixed, k Fixed) error {
+	if len(a) != len(dst) {
+		return arithmeticError("ScaleVec", ErrLength)
+	}
+	a = a[:len(dst)]
+	failed := -1
+	y := k.fp
+	for i := range dst {
+		x := a[i].fp
+		hi, lo := bits.Mul64(x, y)
+		if hi != 0 || x >= reserved || y >= reserved {
+			dst[i].fp, failed = vecSlow('*', a[i], k, i, failed)
+			continue
+		}
+		dst[i].fp = lo / scale
+	}
+	return vecError("ScaleVec", failed)
+}
+
+// DotProduct returns the sum of a[i] * b[i]. The products are summed exactly in 128 bits and truncated
+// to 8 places once, so the result may differ in the last place from summing Mul results. NaN and Inf
+// follow the rules of Mul and Add. An error is returned if the slices differ in length or the result is
+// too large
+func DotProduct(a, b []Fixed) (Fixed, error) {
+	if len(a) != len(b) {
+		return NaN, arithmeticError("DotProduct", ErrLength)
+	}
+	b = b[:len(a)]
+	var sum uint128
+	var special Fixed
+	overflow := false
+	for i := range a {
+		x, y := a[i].fp, b[i].fp
+		if x >= reserved || y >= reserved {
+			special = special.Add(a[i].Mul(b[i]))
+			continue
+		}
+		hi, lo := bits.Mul64(x, y)
+		var carry uint64
+		sum.lo, carry = bits.Add64(sum.lo, lo, 0)
+		sum.hi, carry = bits.Add64(sum.hi, hi, carry)
+		// past 2^128 the result is far beyond MAX
+		overflow = overflow || carry != 0
+	}
+	if special.fp != 0 {
+		return special, nil
+	}
+	q, _ := sum.quoRem64(scale)
+	if overflow || q.hi != 0 || q.lo > MAX.fp {
+		return NaN, arithmeticError("DotProduct", ErrOverflow)
+	}
+	return Fixed{fp: q.lo}, nil
+}
+
+// vecSlow applies op to x and y with the full rules of the Fixed methods, returning the encoding of the
+// result, or of NaN if it overflows, and the index of the first element to overflow
+func vecSlow(op byte, x, y Fixed, i, failed int) (uint64, int) {
+	result, done, err := special(op, x, y)
+	if !done {
+		var ok bool
+		switch op {
+		case '+':
+			result, ok = x.add(y)
+		case '-':
+			result, ok = x.sub(y)
+		case '*':
+			result, ok = x.mul(y)
+		}
+		if !ok {
+			err = ErrOverflow
+		}
+	}
+	if err != nil && failed == -1 {
+		failed = i
+	}
+	return result.fp, failed
+}
+
+// vecError reports the element at index failed, if any. As dst may be an operand, which is overwritten
+// by then, the element is identified by its index alone
+func vecError(op string, failed int) error {
+	if failed == -1 {
+		return nil
+	}
+	return fmt.Errorf("element %d: %w", failed, arithmeticError(op, ErrOverflow))
+}
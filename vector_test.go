@@ -0,0 +1,72 @@
+package fixed_test
+
+import (
+	"errors"
+	. "github.com/cryptowrold/fixed"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+	a := []Fixed{NewFromString("1.5"), NewFromString("2"), NewFromString("0.1"), NaN}
+	b := []Fixed{NewFromString("2"), NewFromString("0.25"), Inf, ONE}
+	dst := make([]Fixed, len(a))
+
+	check := func(op string, err error, want ...string) {
+		t.Helper()
+		if err != nil {
+			t.Error(op, err)
+		}
+		for i, f := range dst {
+			if f.String() != want[i] {
+				t.Error(op, "should be equal", i, f, want[i])
+			}
+		}
+	}
+	check("AddVec", AddVec(dst, a, b), "3.5", "2.25", "Inf", "NaN")
+	check("MulVec", MulVec(dst, a, b), "3", "0.5", "Inf", "NaN")
+	check("ScaleVec", ScaleVec(dst, a, TWO), "3", "4", "0.2", "NaN")
+	check("SubVec", SubVec(dst, []Fixed{TWO, TWO, Inf, TWO}, a), "0.5", "0", "Inf", "NaN")
+	if err := SubVec(dst, b, a); !errors.Is(err, ErrOverflow) || !dst[1].IsNaN() {
+		t.Error("should not go negative", dst[1], err)
+	}
+
+	// in place, with the first of several overflows reported
+	x := []Fixed{ONE, MAX, TWO, MAX}
+	err := AddVec(x, x, x)
+	var ae *ArithmeticError
+	if !errors.Is(err, ErrOverflow) || !errors.As(err, &ae) || err.Error() != "element 1: fixed: AddVec: integer overflow" {
+		t.Error("should overflow at element 1", err)
+	}
+	if !x[0].Equal(TWO) || !x[1].IsNaN() || !x[2].Equal(FOUR) || !x[3].IsNaN() {
+		t.Error("other elements should be computed", x)
+	}
+	if err := MulVec(dst[:1], []Fixed{MAX}, []Fixed{TEN}); !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", err)
+	}
+	if err := AddVec(dst, a, b[:1]); !errors.Is(err, ErrLength) {
+		t.Error("should be a length mismatch", err)
+	}
+}
+
+func TestDotProduct(t *testing.T) {
+	prices := []Fixed{NewFromString("100.5"), NewFromString("0.00000001"), NewFromString("0.00000001")}
+	sizes := []Fixed{NewFromString("3"), NewFromString("0.5"), NewFromString("0.5")}
+
+	// the two half units of the last place are summed before truncating
+	f, err := DotProduct(prices, sizes)
+	if err != nil || f.String() != "301.50000001" {
+		t.Error("should be equal", f, "301.50000001", err)
+	}
+	f, err = DotProduct([]Fixed{MAX, MAX}, []Fixed{ONE, NewFromString("0.00000001")})
+	if !errors.Is(err, ErrOverflow) {
+		t.Error("should overflow", f, err)
+	}
+	f, _ = DotProduct([]Fixed{MAX, ONE}, []Fixed{MAX, Inf})
+	if !f.IsInf() {
+		t.Error("should be Inf", f)
+	}
+	f, _ = DotProduct([]Fixed{ONE, NaN}, []Fixed{ONE, ONE})
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}